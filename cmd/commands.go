@@ -63,6 +63,11 @@ func init() {
 				Ui: ui,
 			}, nil
 		},
+		"maintenance": func() (cli.Command, error) {
+			return &commands.MaintenanceCommand{
+				Ui: ui,
+			}, nil
+		},
 		"version": func() (cli.Command, error) {
 			return &commands.VersionCommand{
 				Version:        Version,
@@ -19,8 +19,10 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"github.com/Syleron/PulseHA/proto"
+	"github.com/Syleron/PulseHA/src/netUtils"
 	"github.com/mitchellh/cli"
 	"github.com/olekukonko/tablewriter"
 	"google.golang.org/grpc"
@@ -45,6 +47,10 @@ Options:
   - ips - Selected floating IPs separated by a comma.
   - node - Node hostname.
   - iface - Node network interface.
+  - vlan - Create a VLAN interface with this ID on iface when assigning.
+  - macvlan - Create iface as a macvlan interface on this parent when assigning.
+  - bond - Create iface as a bond of these interfaces separated by a comma when assigning.
+  - mode - The macvlan or bonding mode.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -60,6 +66,10 @@ func (c *GroupsCommand) Run(args []string) int {
 	fIPs := cmdFlags.String("ips", "", "Floating IPs")
 	nodeHostname := cmdFlags.String("node", "", "Node hostname")
 	nodeIface := cmdFlags.String("iface", "", "Node network interface")
+	vlan := cmdFlags.Int("vlan", 0, "VLAN ID")
+	macvlan := cmdFlags.String("macvlan", "", "Macvlan parent interface")
+	bond := cmdFlags.String("bond", "", "Bond slave interfaces")
+	mode := cmdFlags.String("mode", "", "Macvlan or bonding mode")
 
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
@@ -94,7 +104,12 @@ func (c *GroupsCommand) Run(args []string) int {
 	case "remove":
 		return c.Remove(groupName, fIPs, client)
 	case "assign":
-		return c.Assign(groupName, nodeHostname, nodeIface, client)
+		iface, spec, err := interfaceSpec(*nodeIface, *vlan, *macvlan, *bond, *mode)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		return c.Assign(groupName, nodeHostname, &iface, spec, client)
 	case "unassign":
 		return c.Unassign(groupName, nodeHostname, nodeIface, client)
 	default:
@@ -131,6 +146,8 @@ func (c *GroupsCommand) drawGroupsTable(client proto.CLIClient) {
 					strings.Join(group.Ip, ", "),
 					strings.Join(group.Nodes, "\n"),
 					strings.Join(group.Interfaces, "\n"),
+					group.Owner,
+					strings.Join(group.Routes, "\n"),
 				})
 		}
 		table := tablewriter.NewWriter(os.Stdout)
@@ -139,6 +156,8 @@ func (c *GroupsCommand) drawGroupsTable(client proto.CLIClient) {
 			"IP Assignments",
 			"Nodes",
 			"Ifaces",
+			"Owner",
+			"Routes",
 		})
 		table.SetCenterSeparator("-")
 		table.SetColumnSeparator("|")
@@ -264,7 +283,7 @@ func (c *GroupsCommand) Remove(groupName, fIPs *string, client proto.CLIClient)
 /**
  *
  */
-func (c *GroupsCommand) Assign(groupName, nodeHostname, nodeIface *string, client proto.CLIClient) int {
+func (c *GroupsCommand) Assign(groupName, nodeHostname, nodeIface *string, spec *proto.InterfaceSpec, client proto.CLIClient) int {
 	if *groupName == "" {
 		c.Ui.Error("Please specify a group name")
 		c.Ui.Error("")
@@ -287,6 +306,7 @@ func (c *GroupsCommand) Assign(groupName, nodeHostname, nodeIface *string, clien
 		Group:     *groupName,
 		Interface: *nodeIface,
 		Node:      *nodeHostname,
+		Spec:      spec,
 	})
 	if err != nil {
 		c.Ui.Output("PulseHA CLI connection error. Is the PulseHA service running?")
@@ -340,3 +360,43 @@ func (c *GroupsCommand) Unassign(groupName, nodeHostname, nodeIface *string, cli
 	}
 	return 0
 }
+
+/**
+ * Returns the interface to assign to and the spec for PulseHA to create it
+ * from when a VLAN, macvlan or bond has been requested
+ */
+func interfaceSpec(iface string, vlan int, macvlan, bond, mode string) (string, *proto.InterfaceSpec, error) {
+	if iface == "" {
+		return iface, nil, nil
+	}
+	requested := 0
+	for _, set := range []bool{vlan != 0, macvlan != "", bond != ""} {
+		if set {
+			requested++
+		}
+	}
+	if requested > 1 {
+		return "", nil, errors.New("only one of vlan, macvlan or bond can be specified")
+	}
+	switch {
+	case vlan != 0:
+		return netUtils.VLANName(iface, vlan), &proto.InterfaceSpec{
+			Type:   netUtils.LinkVLAN,
+			Parent: iface,
+			Vlan:   int32(vlan),
+		}, nil
+	case macvlan != "":
+		return iface, &proto.InterfaceSpec{
+			Type:   netUtils.LinkMacvlan,
+			Parent: macvlan,
+			Mode:   mode,
+		}, nil
+	case bond != "":
+		return iface, &proto.InterfaceSpec{
+			Type:   netUtils.LinkBond,
+			Slaves: strings.Split(bond, ","),
+			Mode:   mode,
+		}, nil
+	}
+	return iface, nil, nil
+}
@@ -0,0 +1,121 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"github.com/Syleron/PulseHA/proto"
+	"github.com/mitchellh/cli"
+	"google.golang.org/grpc"
+	"strings"
+)
+
+type MaintenanceCommand struct {
+	Ui cli.Ui
+}
+
+var errMaintenanceMode = errors.New("Please specify either on or off.")
+
+/**
+ *
+ */
+func (c *MaintenanceCommand) Help() string {
+	helpText := `
+Usage: pulseha maintenance [options] on|off
+  Enable or disable maintenance mode. Without a node the whole
+  cluster is placed in maintenance and automatic failover is disabled.
+Options:
+  -node Hostname of the node to place in maintenance
+`
+	return strings.TrimSpace(helpText)
+}
+
+/**
+ *
+ */
+func (c *MaintenanceCommand) Run(args []string) int {
+	enable, node, err := c.parseArgs(args)
+	if err != nil {
+		// The flag package has already reported any invalid options
+		if err == errMaintenanceMode {
+			c.Ui.Error(err.Error())
+			c.Ui.Error("")
+			c.Ui.Error(c.Help())
+		}
+		return 1
+	}
+	connection, err := grpc.Dial("127.0.0.1:9443", grpc.WithInsecure())
+	if err != nil {
+		c.Ui.Error("GRPC client connection error")
+		c.Ui.Error(err.Error())
+	}
+	defer connection.Close()
+	client := proto.NewCLIClient(connection)
+
+	r, err := client.Maintenance(context.Background(), &proto.PulseMaintenance{
+		Enable: enable,
+		Node:   node,
+	})
+	if err != nil {
+		c.Ui.Output("PulseHA CLI connection error. Is the PulseHA service running?")
+		c.Ui.Output(err.Error())
+	} else {
+		if r.Success {
+			c.Ui.Output("\n[\u2713] " + r.Message + "\n")
+		} else {
+			c.Ui.Output("\n[x] " + r.Message + "\n")
+		}
+	}
+	return 0
+}
+
+/**
+ * Returns whether maintenance should be enabled and for which node.
+ * Options may be given before or after the mode.
+ */
+func (c *MaintenanceCommand) parseArgs(args []string) (bool, string, error) {
+	cmdFlags := flag.NewFlagSet("maintenance", flag.ContinueOnError)
+	cmdFlags.Usage = func() { c.Ui.Output(c.Help()) }
+	node := cmdFlags.String("node", "", "Hostname of the node to place in maintenance")
+	if err := cmdFlags.Parse(args); err != nil {
+		return false, "", err
+	}
+	mode := cmdFlags.Args()
+	// Allow options to be specified after the mode
+	if len(mode) > 1 {
+		if err := cmdFlags.Parse(mode[1:]); err != nil {
+			return false, "", err
+		}
+		if len(cmdFlags.Args()) > 0 {
+			return false, "", errMaintenanceMode
+		}
+	}
+	if len(mode) == 0 || (mode[0] != "on" && mode[0] != "off") {
+		return false, "", errMaintenanceMode
+	}
+	return mode[0] == "on", *node, nil
+}
+
+/**
+ *
+ */
+func (c *MaintenanceCommand) Synopsis() string {
+	return "Enable or disable maintenance mode for a node or the cluster"
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"github.com/mitchellh/cli"
+	"testing"
+)
+
+func TestMaintenanceParseArgs(t *testing.T) {
+	tests := []struct {
+		args   []string
+		enable bool
+		node   string
+		valid  bool
+	}{
+		{[]string{"on"}, true, "", true},
+		{[]string{"off"}, false, "", true},
+		{[]string{"-node", "node1", "on"}, true, "node1", true},
+		{[]string{"off", "-node", "node1"}, false, "node1", true},
+		{[]string{}, false, "", false},
+		{[]string{"maybe"}, false, "", false},
+		{[]string{"on", "-node", "node1", "extra"}, false, "", false},
+		{[]string{"-bogus", "on"}, false, "", false},
+	}
+	for _, test := range tests {
+		c := &MaintenanceCommand{Ui: cli.NewMockUi()}
+		enable, node, err := c.parseArgs(test.args)
+		if (err == nil) != test.valid {
+			t.Errorf("parseArgs(%v) returned error %v", test.args, err)
+			continue
+		}
+		if enable != test.enable || node != test.node {
+			t.Errorf("parseArgs(%v) = %t, %q, want %t, %q", test.args, enable, node, test.enable, test.node)
+		}
+	}
+}
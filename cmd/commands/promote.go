@@ -18,8 +18,12 @@ type PromoteCommand struct {
  */
 func (c *PromoteCommand) Help() string {
 	helpText := `
-Usage: pulseha status [options] ...
+Usage: pulseha promote [options] hostname
+  Promote a member to become the active member. When running
+  active/active a single group can be moved to the member instead.
 Options:
+  -group Only move the specified group to the member
+  -best Promote the passive member with the highest health score instead of a hostname
 `
 	return strings.TrimSpace(helpText)
 }
@@ -28,13 +32,31 @@ Options:
  *
  */
 func (c *PromoteCommand) Run(args []string) int {
-	cmdFlags := flag.NewFlagSet("status", flag.ContinueOnError)
+	cmdFlags := flag.NewFlagSet("promote", flag.ContinueOnError)
 	cmdFlags.Usage = func() { c.Ui.Output(c.Help()) }
+	group := cmdFlags.String("group", "", "Only move the specified group to the member")
+	best := cmdFlags.Bool("best", false, "Promote the passive member with the highest health score")
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
 	}
 	addr := cmdFlags.Args()
-	if len(addr) == 0 {
+	// Allow options to be specified after the hostname
+	if len(addr) > 1 {
+		if err := cmdFlags.Parse(addr[1:]); err != nil {
+			return 1
+		}
+	}
+	member := ""
+	if len(addr) > 0 {
+		member = addr[0]
+	}
+	if *best && (member != "" || *group != "") {
+		c.Ui.Error("-best cannot be combined with a hostname or a group")
+		c.Ui.Error("")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+	if member == "" && !*best {
 		c.Ui.Error("Please specify a node to promote!")
 		c.Ui.Error("")
 		c.Ui.Error(c.Help())
@@ -49,7 +71,8 @@ func (c *PromoteCommand) Run(args []string) int {
 	client := proto.NewCLIClient(connection)
 
 	r, err := client.Promote(context.Background(), &proto.PulsePromote{
-		Member: addr[0],
+		Member: member,
+		Group:  *group,
 	})
 	if err != nil {
 		c.Ui.Output("PulseHA CLI connection error. Is the PulseHA service running?")
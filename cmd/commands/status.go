@@ -25,6 +25,7 @@ import (
 	"github.com/olekukonko/tablewriter"
 	"google.golang.org/grpc"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -73,14 +74,18 @@ func (c *StatusCommand) drawStatusTable(client proto.CLIClient) {
 	} else {
 		data := [][]string{}
 		for _, node := range r.Row {
-
+			status := node.Status.String()
+			if node.Maintenance && node.Status != proto.MemberStatus_MAINTENANCE {
+				status += " (MAINTENANCE)"
+			}
 			data = append(
 				data,
 				[]string{
 					node.Hostname,
 					node.Ip,
 					node.Latency,
-					node.Status.String(),
+					status,
+					strconv.Itoa(int(node.Score)),
 					node.LastReceived,
 				})
 		}
@@ -90,6 +95,7 @@ func (c *StatusCommand) drawStatusTable(client proto.CLIClient) {
 			"Bind Address",
 			"Latency",
 			"Status",
+			"Score",
 			"Last Received",
 		})
 		table.SetCenterSeparator("-")
@@ -98,6 +104,95 @@ func (c *StatusCommand) drawStatusTable(client proto.CLIClient) {
 		table.SetAutoMergeCells(true)
 		table.AppendBulk(data)
 		table.Render()
+		if len(r.Owners) > 0 {
+			owners := [][]string{}
+			for _, owner := range r.Owners {
+				owners = append(owners, []string{owner.Group, owner.Owner})
+			}
+			ownerTable := tablewriter.NewWriter(os.Stdout)
+			ownerTable.SetHeader([]string{
+				"Group Name",
+				"Owner",
+			})
+			ownerTable.SetCenterSeparator("-")
+			ownerTable.SetColumnSeparator("|")
+			ownerTable.SetRowLine(true)
+			ownerTable.AppendBulk(owners)
+			ownerTable.Render()
+		}
+		paths := [][]string{}
+		for _, row := range r.Row {
+			// Only worth showing when there is more than one path to the node
+			if len(row.Paths) < 2 {
+				continue
+			}
+			for _, path := range row.Paths {
+				state := "UP"
+				if !path.Up {
+					state = "DOWN"
+				}
+				paths = append(paths, []string{row.Hostname, path.Address, state, path.Latency, path.LastReceived})
+			}
+		}
+		if len(paths) > 0 {
+			pathTable := tablewriter.NewWriter(os.Stdout)
+			pathTable.SetHeader([]string{
+				"Node Hostname",
+				"Heartbeat Path",
+				"State",
+				"Latency",
+				"Last Received",
+			})
+			pathTable.SetCenterSeparator("-")
+			pathTable.SetColumnSeparator("|")
+			pathTable.SetRowLine(true)
+			pathTable.SetAutoMergeCells(true)
+			pathTable.AppendBulk(paths)
+			pathTable.Render()
+		}
+		if len(r.Checks) > 0 {
+			checks := [][]string{}
+			for _, check := range r.Checks {
+				state := "HEALTHY"
+				if !check.Healthy {
+					state = "FAILING"
+				}
+				checks = append(checks, []string{check.Name, check.Type, state, check.Message, check.LastCheck})
+			}
+			checkTable := tablewriter.NewWriter(os.Stdout)
+			checkTable.SetHeader([]string{
+				"Check",
+				"Type",
+				"State",
+				"Message",
+				"Last Check",
+			})
+			checkTable.SetCenterSeparator("-")
+			checkTable.SetColumnSeparator("|")
+			checkTable.SetRowLine(true)
+			checkTable.AppendBulk(checks)
+			checkTable.Render()
+		}
+		if r.Maintenance {
+			c.Ui.Output("\nCluster is in maintenance mode. Automatic failover is disabled.\n")
+		}
+		if r.Damped {
+			c.Ui.Output("\nThe active role has been flapping. Automatic failover is suppressed but members can still be promoted manually.\n")
+		}
+		if len(r.Flaps) > 0 {
+			c.Ui.Output("\nRecent role changes:")
+			for _, event := range r.Flaps {
+				c.Ui.Output("  " + event)
+			}
+			c.Ui.Output("")
+		}
+		if len(r.Drift) > 0 {
+			c.Ui.Output("\nRecent floating IP drift on this node:")
+			for _, event := range r.Drift {
+				c.Ui.Output("  " + event)
+			}
+			c.Ui.Output("")
+		}
 	}
 }
 
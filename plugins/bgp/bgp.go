@@ -0,0 +1,265 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+/**
+Networking plugin for routed data centres where floating IPs cannot be moved
+with ARP. Instead of adding addresses to an interface each floating IP is
+announced to the configured BGP peers as a /32 or /128 route when the node goes
+active and withdrawn again when it goes passive. The interface is ignored.
+
+Configured with plugins/bgp.json:
+	{
+		"local_as": 65001,
+		"router_id": "10.0.0.1",
+		"next_hop": "10.0.0.1",
+		"next_hop_v6": "2001:db8::1",
+		"hold_time": 90,
+		"peers": [
+			{"address": "10.0.0.254", "as": 65000}
+		]
+	}
+
+The router ID and next hops default to the local address of each peer session.
+*/
+type BGP struct {
+	LocalAS   uint32 `json:"local_as"`
+	RouterID  string `json:"router_id"`
+	NextHop   string `json:"next_hop"`
+	NextHopV6 string `json:"next_hop_v6"`
+	// Seconds. Keepalives are sent every third of the negotiated hold time
+	HoldTime int    `json:"hold_time"`
+	Peers    []Peer `json:"peers"`
+	// Make sure we only load our config and start our sessions once
+	once     sync.Once
+	err      error
+	sessions []*session
+	// Serialises route updates with sessions coming up so neither misses the other
+	updates sync.Mutex
+	// The prefixes we are currently announcing
+	mu        sync.Mutex
+	announced map[string]net.IPNet
+	done      chan struct{}
+}
+
+/**
+A BGP neighbour. The port defaults to 179.
+*/
+type Peer struct {
+	Address string `json:"address"`
+	Port    int    `json:"port"`
+	AS      uint32 `json:"as"`
+}
+
+var PluginNet BGP
+
+/**
+Note: Required to build the package. Plugins are built with -buildmode=plugin.
+*/
+func main() {}
+
+/**
+Returns the plugin name
+*/
+func (b *BGP) Name() string {
+	return "BGP"
+}
+
+/**
+Returns the plugin version
+*/
+func (b *BGP) Version() float64 {
+	return 1.0
+}
+
+/**
+Load our config from the plugins directory and start our peer sessions
+*/
+func (b *BGP) setup() {
+	dir, err := filepath.Abs(filepath.Dir(os.Args[0]))
+	if err != nil {
+		b.err = err
+		return
+	}
+	buf, err := ioutil.ReadFile(dir + "/plugins/bgp.json")
+	if err != nil {
+		b.err = errors.New("unable to read bgp config: " + err.Error())
+		return
+	}
+	if err := json.Unmarshal(buf, b); err != nil {
+		b.err = errors.New("invalid bgp config: " + err.Error())
+		return
+	}
+	b.start()
+}
+
+/**
+Start a session for each configured peer
+*/
+func (b *BGP) start() {
+	if b.HoldTime <= 0 {
+		b.HoldTime = 90
+	}
+	b.announced = make(map[string]net.IPNet)
+	b.done = make(chan struct{})
+	for _, peer := range b.Peers {
+		if peer.Port == 0 {
+			peer.Port = 179
+		}
+		s := &session{
+			bgp:     b,
+			peer:    peer,
+			address: net.JoinHostPort(peer.Address, strconv.Itoa(peer.Port)),
+		}
+		b.sessions = append(b.sessions, s)
+		go s.run()
+	}
+}
+
+/**
+Stop all peer sessions
+*/
+func (b *BGP) stop() {
+	close(b.done)
+	for _, s := range b.sessions {
+		s.close()
+	}
+}
+
+/**
+Returns the host route for an ip
+*/
+func hostRoute(ip string) (net.IPNet, error) {
+	// Group IPs are stored in CIDR form but we only announce the address itself
+	addr, _, err := net.ParseCIDR(ip)
+	if err != nil {
+		addr = net.ParseIP(ip)
+	}
+	if addr == nil {
+		return net.IPNet{}, errors.New("invalid ip address " + ip)
+	}
+	if v4 := addr.To4(); v4 != nil {
+		return net.IPNet{IP: v4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return net.IPNet{IP: addr, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+/**
+Returns a copy of the prefixes we are announcing
+*/
+func (b *BGP) routes() []net.IPNet {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var routes []net.IPNet
+	for _, route := range b.announced {
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+/**
+Returns the host routes for the ips
+*/
+func hostRoutes(ips []string) ([]net.IPNet, error) {
+	var routes []net.IPNet
+	for _, ip := range ips {
+		route, err := hostRoute(ip)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+/**
+Announce the ips to our peers. Peers that are not yet established receive
+them once their session comes up. The ips are only recorded as announced once
+every established peer has been sent them.
+*/
+func (b *BGP) BringUpIPs(iface string, ips []string) error {
+	b.once.Do(b.setup)
+	if b.err != nil {
+		return b.err
+	}
+	if len(b.sessions) == 0 {
+		return errors.New("no bgp peers have been configured")
+	}
+	routes, err := hostRoutes(ips)
+	if err != nil {
+		return err
+	}
+	b.updates.Lock()
+	defer b.updates.Unlock()
+	for _, s := range b.sessions {
+		if sendErr := s.announce(routes); sendErr != nil {
+			err = sendErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	for _, route := range routes {
+		b.announced[route.String()] = route
+	}
+	b.mu.Unlock()
+	return nil
+}
+
+/**
+Withdraw the ips from our peers. The ips are only forgotten once every
+established peer has been told.
+*/
+func (b *BGP) BringDownIPs(iface string, ips []string) error {
+	b.once.Do(b.setup)
+	if b.err != nil {
+		return b.err
+	}
+	routes, err := hostRoutes(ips)
+	if err != nil {
+		return err
+	}
+	b.updates.Lock()
+	defer b.updates.Unlock()
+	for _, s := range b.sessions {
+		if sendErr := s.withdraw(routes); sendErr != nil {
+			err = sendErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	for _, route := range routes {
+		delete(b.announced, route.String())
+	}
+	b.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,204 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+)
+
+/**
+A minimal in-process BGP speaker which accepts a single session and reports
+each prefix announced (+) or withdrawn (-)
+*/
+type testSpeaker struct {
+	listener net.Listener
+	events   chan string
+	last     string
+}
+
+func newTestSpeaker(t *testing.T, as uint32) *testSpeaker {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp := &testSpeaker{listener: l, events: make(chan string, 16)}
+	go sp.serve(as)
+	return sp
+}
+
+func (sp *testSpeaker) port() int {
+	return sp.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (sp *testSpeaker) serve(as uint32) {
+	conn, err := sp.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	if msgType, _, err := readMessage(conn); err != nil || msgType != msgOpen {
+		return
+	}
+	conn.Write(openMessage(as, 90, net.ParseIP("127.0.0.2")))
+	conn.Write(message(msgKeepalive, nil))
+	for {
+		msgType, body, err := readMessage(conn)
+		if err != nil {
+			return
+		}
+		if msgType == msgUpdate {
+			sp.update(body)
+		}
+	}
+}
+
+func (sp *testSpeaker) update(body []byte) {
+	withdrawnLen := int(binary.BigEndian.Uint16(body))
+	sp.prefixes("-", 4, body[2:2+withdrawnLen])
+	body = body[2+withdrawnLen:]
+	attrsLen := int(binary.BigEndian.Uint16(body))
+	attrs := body[2 : 2+attrsLen]
+	sp.prefixes("+", 4, body[2+attrsLen:])
+	for len(attrs) > 0 {
+		flags, code := attrs[0], attrs[1]
+		valueLen, offset := int(attrs[2]), 3
+		if flags&flagExtended != 0 {
+			valueLen, offset = int(binary.BigEndian.Uint16(attrs[2:])), 4
+		}
+		value := attrs[offset : offset+valueLen]
+		switch code {
+		case attrMPReach:
+			nextHopLen := int(value[3])
+			sp.prefixes("+", 16, value[5+nextHopLen:])
+		case attrMPUnreach:
+			sp.prefixes("-", 16, value[3:])
+		}
+		attrs = attrs[offset+valueLen:]
+	}
+}
+
+func (sp *testSpeaker) prefixes(action string, size int, nlri []byte) {
+	for len(nlri) > 0 {
+		bits := int(nlri[0])
+		ip := make(net.IP, size)
+		copy(ip, nlri[1:1+(bits+7)/8])
+		prefix := net.IPNet{IP: ip, Mask: net.CIDRMask(bits, size*8)}
+		sp.events <- action + prefix.String()
+		nlri = nlri[1+(bits+7)/8:]
+	}
+}
+
+/**
+Note: A route announced while the session is being established may be sent
+twice so repeats are ignored.
+*/
+func (sp *testSpeaker) expect(t *testing.T, want ...string) {
+	for _, w := range want {
+		select {
+		case got := <-sp.events:
+			for got == sp.last {
+				got = <-sp.events
+			}
+			if got != w {
+				t.Errorf("speaker received %s, want %s", got, w)
+			}
+			sp.last = got
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s", w)
+		}
+	}
+}
+
+func TestBGPAnnounceWithdraw(t *testing.T) {
+	speaker := newTestSpeaker(t, 65000)
+	defer speaker.listener.Close()
+	b := &BGP{
+		LocalAS:   65001,
+		NextHopV6: "2001:db8::1",
+		Peers:     []Peer{{Address: "127.0.0.1", Port: speaker.port(), AS: 65000}},
+	}
+	b.once.Do(b.start)
+	defer b.stop()
+	// Routes announced before the session is up are sent once it is established
+	if err := b.BringUpIPs("eth0", []string{"10.1.1.1"}); err != nil {
+		t.Fatal(err)
+	}
+	speaker.expect(t, "+10.1.1.1/32")
+	if err := b.BringUpIPs("eth0", []string{"2001:db8::10"}); err != nil {
+		t.Fatal(err)
+	}
+	speaker.expect(t, "+2001:db8::10/128")
+	if err := b.BringDownIPs("eth0", []string{"10.1.1.1", "2001:db8::10"}); err != nil {
+		t.Fatal(err)
+	}
+	speaker.expect(t, "-10.1.1.1/32", "-2001:db8::10/128")
+}
+
+func TestBGPAnnounceCIDR(t *testing.T) {
+	speaker := newTestSpeaker(t, 65000)
+	defer speaker.listener.Close()
+	b := &BGP{
+		LocalAS:   65001,
+		NextHopV6: "2001:db8::1",
+		Peers:     []Peer{{Address: "127.0.0.1", Port: speaker.port(), AS: 65000}},
+	}
+	b.once.Do(b.start)
+	defer b.stop()
+	// Group IPs are stored with their prefix length
+	if err := b.BringUpIPs("eth0", []string{"10.0.0.5/24"}); err != nil {
+		t.Fatal(err)
+	}
+	speaker.expect(t, "+10.0.0.5/32")
+	if err := b.BringUpIPs("eth0", []string{"2001:db8::10/64"}); err != nil {
+		t.Fatal(err)
+	}
+	speaker.expect(t, "+2001:db8::10/128")
+	if err := b.BringDownIPs("eth0", []string{"10.0.0.5/24"}); err != nil {
+		t.Fatal(err)
+	}
+	speaker.expect(t, "-10.0.0.5/32")
+}
+
+func TestBGPInvalidIP(t *testing.T) {
+	b := &BGP{Peers: []Peer{{Address: "127.0.0.1", Port: 1}}}
+	b.once.Do(b.start)
+	defer b.stop()
+	if err := b.BringUpIPs("eth0", []string{"not an ip"}); err == nil {
+		t.Error("BringUpIPs() should fail for an invalid ip")
+	}
+}
+
+func TestBGPFailedUpdate(t *testing.T) {
+	local, remote := net.Pipe()
+	remote.Close()
+	b := &BGP{LocalAS: 65001, announced: make(map[string]net.IPNet)}
+	b.once.Do(func() {})
+	b.sessions = []*session{{bgp: b, address: "192.0.2.1:179", conn: local, established: true, nextHop: net.ParseIP("10.0.0.1")}}
+	if err := b.BringUpIPs("eth0", []string{"10.0.0.10"}); err == nil {
+		t.Fatal("BringUpIPs() should fail when the update cannot be written")
+	}
+	if routes := b.routes(); len(routes) != 0 {
+		t.Errorf("routes() = %v, want none after a failed update", routes)
+	}
+	if b.sessions[0].established {
+		t.Error("session should no longer be established after a failed write")
+	}
+}
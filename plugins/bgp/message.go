@@ -0,0 +1,263 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+)
+
+/**
+BGP-4 message types (RFC 4271)
+*/
+const (
+	msgOpen         = 1
+	msgUpdate       = 2
+	msgNotification = 3
+	msgKeepalive    = 4
+)
+
+/**
+Path attributes, capabilities and address families used in our messages
+*/
+const (
+	attrOrigin      = 1
+	attrASPath      = 2
+	attrNextHop     = 3
+	attrLocalPref   = 5
+	attrMPReach     = 14
+	attrMPUnreach   = 15
+	flagOptional    = 0x80
+	flagTransitive  = 0x40
+	flagExtended    = 0x10
+	capMultiProto   = 1
+	capFourOctetAS  = 65
+	afiIPv4         = 1
+	afiIPv6         = 2
+	safiUnicast     = 1
+	asTrans         = 23456
+	headerLen       = 19
+	maxMessageLen   = 4096
+	segmentSequence = 2
+)
+
+/**
+What we learnt from our peer's OPEN message
+*/
+type openMsg struct {
+	AS       uint32
+	HoldTime uint16
+	ID       net.IP
+	AS4      bool
+}
+
+/**
+Frame a message with the BGP header
+*/
+func message(msgType byte, body []byte) []byte {
+	msg := make([]byte, headerLen, headerLen+len(body))
+	for i := 0; i < 16; i++ {
+		msg[i] = 0xff
+	}
+	binary.BigEndian.PutUint16(msg[16:], uint16(headerLen+len(body)))
+	msg[18] = msgType
+	return append(msg, body...)
+}
+
+/**
+Read a single message returning its type and body
+*/
+func readMessage(r io.Reader) (byte, []byte, error) {
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, err
+	}
+	for i := 0; i < 16; i++ {
+		if header[i] != 0xff {
+			return 0, nil, errors.New("invalid bgp message marker")
+		}
+	}
+	length := int(binary.BigEndian.Uint16(header[16:]))
+	if length < headerLen || length > maxMessageLen {
+		return 0, nil, errors.New("invalid bgp message length")
+	}
+	body := make([]byte, length-headerLen)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return header[18], body, nil
+}
+
+/**
+Build an OPEN message advertising IPv4 and IPv6 unicast and four octet AS numbers
+*/
+func openMessage(as uint32, holdTime uint16, id net.IP) []byte {
+	caps := []byte{
+		capMultiProto, 4, 0, afiIPv4, 0, safiUnicast,
+		capMultiProto, 4, 0, afiIPv6, 0, safiUnicast,
+		capFourOctetAS, 4, 0, 0, 0, 0,
+	}
+	binary.BigEndian.PutUint32(caps[14:], as)
+	body := make([]byte, 10, 12+len(caps))
+	body[0] = 4
+	myAS := uint16(asTrans)
+	if as <= 0xffff {
+		myAS = uint16(as)
+	}
+	binary.BigEndian.PutUint16(body[1:], myAS)
+	binary.BigEndian.PutUint16(body[3:], holdTime)
+	copy(body[5:9], id.To4())
+	body[9] = byte(2 + len(caps))
+	body = append(body, 2, byte(len(caps)))
+	body = append(body, caps...)
+	return message(msgOpen, body)
+}
+
+/**
+Parse a peer's OPEN message
+*/
+func parseOpen(body []byte) (*openMsg, error) {
+	if len(body) < 10 || body[0] != 4 {
+		return nil, errors.New("unsupported bgp open message")
+	}
+	open := &openMsg{
+		AS:       uint32(binary.BigEndian.Uint16(body[1:])),
+		HoldTime: binary.BigEndian.Uint16(body[3:]),
+		ID:       net.IP(body[5:9]),
+	}
+	params := body[10:]
+	if len(params) != int(body[9]) {
+		return nil, errors.New("invalid bgp open optional parameters")
+	}
+	for len(params) >= 2 {
+		paramType, paramLen := params[0], int(params[1])
+		if len(params) < 2+paramLen {
+			return nil, errors.New("invalid bgp open optional parameters")
+		}
+		caps := params[2 : 2+paramLen]
+		params = params[2+paramLen:]
+		if paramType != 2 {
+			continue
+		}
+		for len(caps) >= 2 {
+			code, capLen := caps[0], int(caps[1])
+			if len(caps) < 2+capLen {
+				return nil, errors.New("invalid bgp capability")
+			}
+			if code == capFourOctetAS && capLen == 4 {
+				open.AS4 = true
+				open.AS = binary.BigEndian.Uint32(caps[2:])
+			}
+			caps = caps[2+capLen:]
+		}
+	}
+	return open, nil
+}
+
+/**
+Encode a prefix in NLRI form
+*/
+func encodePrefix(prefix net.IPNet) []byte {
+	ones, _ := prefix.Mask.Size()
+	ip := prefix.IP.To4()
+	if ip == nil {
+		ip = prefix.IP.To16()
+	}
+	return append([]byte{byte(ones)}, ip[:(ones+7)/8]...)
+}
+
+/**
+Encode a path attribute
+*/
+func attribute(flags, code byte, value []byte) []byte {
+	if len(value) > 0xff {
+		attr := []byte{flags | flagExtended, code, 0, 0}
+		binary.BigEndian.PutUint16(attr[2:], uint16(len(value)))
+		return append(attr, value...)
+	}
+	return append([]byte{flags, code, byte(len(value))}, value...)
+}
+
+/**
+Build the path attributes for our announcements. iBGP peers receive an
+empty AS path and a local preference.
+*/
+func pathAttributes(localAS, peerAS uint32, as4 bool) []byte {
+	attrs := attribute(flagTransitive, attrOrigin, []byte{0})
+	if localAS == peerAS {
+		attrs = append(attrs, attribute(flagTransitive, attrASPath, nil)...)
+		return append(attrs, attribute(flagTransitive, attrLocalPref, []byte{0, 0, 0, 100})...)
+	}
+	path := []byte{segmentSequence, 1}
+	if as4 {
+		path = append(path, 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(path[2:], localAS)
+	} else {
+		asn := uint16(asTrans)
+		if localAS <= 0xffff {
+			asn = uint16(localAS)
+		}
+		path = append(path, 0, 0)
+		binary.BigEndian.PutUint16(path[2:], asn)
+	}
+	return append(attrs, attribute(flagTransitive, attrASPath, path)...)
+}
+
+/**
+Build an UPDATE announcing a prefix. IPv4 prefixes use the NLRI field while
+IPv6 prefixes are carried in MP_REACH_NLRI.
+*/
+func announceMessage(prefix net.IPNet, attrs []byte, nextHop net.IP) []byte {
+	if v4 := prefix.IP.To4(); v4 != nil {
+		attrs = append(attrs, attribute(flagTransitive, attrNextHop, nextHop.To4())...)
+		return updateMessage(nil, attrs, encodePrefix(prefix))
+	}
+	reach := []byte{0, afiIPv6, safiUnicast, 16}
+	reach = append(reach, nextHop.To16()...)
+	reach = append(reach, 0)
+	reach = append(reach, encodePrefix(prefix)...)
+	attrs = append(attrs, attribute(flagOptional, attrMPReach, reach)...)
+	return updateMessage(nil, attrs, nil)
+}
+
+/**
+Build an UPDATE withdrawing a prefix
+*/
+func withdrawMessage(prefix net.IPNet) []byte {
+	if v4 := prefix.IP.To4(); v4 != nil {
+		return updateMessage(encodePrefix(prefix), nil, nil)
+	}
+	unreach := append([]byte{0, afiIPv6, safiUnicast}, encodePrefix(prefix)...)
+	return updateMessage(nil, attribute(flagOptional, attrMPUnreach, unreach), nil)
+}
+
+/**
+Frame an UPDATE from its withdrawn routes, path attributes and NLRI
+*/
+func updateMessage(withdrawn, attrs, nlri []byte) []byte {
+	body := make([]byte, 2, 4+len(withdrawn)+len(attrs)+len(nlri))
+	binary.BigEndian.PutUint16(body, uint16(len(withdrawn)))
+	body = append(body, withdrawn...)
+	body = append(body, 0, 0)
+	binary.BigEndian.PutUint16(body[2+len(withdrawn):], uint16(len(attrs)))
+	body = append(body, attrs...)
+	body = append(body, nlri...)
+	return message(msgUpdate, body)
+}
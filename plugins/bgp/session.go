@@ -0,0 +1,262 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"errors"
+	log "github.com/Sirupsen/logrus"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+/**
+How long to wait for a peer to accept our connection and how long to
+wait before trying again once a session has gone down
+*/
+const (
+	connectTimeout = 5 * time.Second
+	retryInterval  = 5 * time.Second
+)
+
+/**
+A session with a single BGP peer
+*/
+type session struct {
+	sync.Mutex
+	bgp         *BGP
+	peer        Peer
+	address     string
+	conn        net.Conn
+	established bool
+	// Negotiated with the peer when the session is opened
+	peerAS  uint32
+	as4     bool
+	nextHop net.IP
+	nextV6  net.IP
+}
+
+/**
+Keep the session up until the plugin is stopped
+*/
+func (s *session) run() {
+	for {
+		err := s.connect()
+		select {
+		case <-s.bgp.done:
+			return
+		default:
+		}
+		if err != nil {
+			log.Warnf("BGP session with %s failed: %s", s.address, err.Error())
+		}
+		select {
+		case <-s.bgp.done:
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+/**
+Close the session. It is re-established by run.
+*/
+func (s *session) close() {
+	s.Lock()
+	defer s.Unlock()
+	s.established = false
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+/**
+Open a session with the peer, send it everything we are announcing and
+then hold the session open until it fails
+*/
+func (s *session) connect() error {
+	conn, err := net.DialTimeout("tcp", s.address, connectTimeout)
+	if err != nil {
+		return err
+	}
+	defer s.close()
+	select {
+	case <-s.bgp.done:
+		return errors.New("the bgp plugin has been stopped")
+	default:
+	}
+	s.Lock()
+	s.conn = conn
+	s.Unlock()
+	local := conn.LocalAddr().(*net.TCPAddr).IP
+	routerID := net.ParseIP(s.bgp.RouterID)
+	if routerID == nil {
+		routerID = local
+	}
+	if routerID.To4() == nil {
+		return errors.New("a router id is required when peering over ipv6")
+	}
+	conn.SetDeadline(time.Now().Add(time.Duration(s.bgp.HoldTime) * time.Second))
+	if _, err := conn.Write(openMessage(s.bgp.LocalAS, uint16(s.bgp.HoldTime), routerID)); err != nil {
+		return err
+	}
+	msgType, body, err := readMessage(conn)
+	if err != nil {
+		return err
+	}
+	if msgType != msgOpen {
+		return errors.New("expected an open message from " + s.address)
+	}
+	open, err := parseOpen(body)
+	if err != nil {
+		return err
+	}
+	if s.peer.AS != 0 && open.AS != s.peer.AS {
+		// Bad peer AS
+		conn.Write(message(msgNotification, []byte{2, 2}))
+		return errors.New("peer " + s.address + " has unexpected as " + strconv.FormatUint(uint64(open.AS), 10))
+	}
+	if _, err := conn.Write(message(msgKeepalive, nil)); err != nil {
+		return err
+	}
+	if msgType, _, err = readMessage(conn); err != nil {
+		return err
+	}
+	if msgType != msgKeepalive {
+		return errors.New("expected a keepalive message from " + s.address)
+	}
+	// The lower of the two hold times is used and zero disables keepalives
+	holdTime := time.Duration(s.bgp.HoldTime) * time.Second
+	if peerHold := time.Duration(open.HoldTime) * time.Second; peerHold < holdTime {
+		holdTime = peerHold
+	}
+	conn.SetDeadline(time.Time{})
+	s.bgp.updates.Lock()
+	err = s.establish(open, local)
+	s.bgp.updates.Unlock()
+	if err != nil {
+		return err
+	}
+	if holdTime > 0 {
+		go s.keepalive(conn, holdTime/3)
+	}
+	for {
+		if holdTime > 0 {
+			conn.SetReadDeadline(time.Now().Add(holdTime))
+		}
+		msgType, _, err := readMessage(conn)
+		if err != nil {
+			return err
+		}
+		if msgType == msgNotification {
+			return errors.New("peer " + s.address + " closed the session")
+		}
+	}
+}
+
+/**
+Mark the session as established and send our routes
+*/
+func (s *session) establish(open *openMsg, local net.IP) error {
+	s.Lock()
+	defer s.Unlock()
+	s.peerAS = open.AS
+	s.as4 = open.AS4
+	s.nextHop = net.ParseIP(s.bgp.NextHop)
+	s.nextV6 = net.ParseIP(s.bgp.NextHopV6)
+	if s.nextHop == nil && local.To4() != nil {
+		s.nextHop = local
+	}
+	if s.nextV6 == nil && local.To4() == nil {
+		s.nextV6 = local
+	}
+	s.established = true
+	return s.send(s.bgp.routes(), true)
+}
+
+/**
+Send keepalives until the session is closed
+*/
+func (s *session) keepalive(conn net.Conn, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.Lock()
+		if s.conn != conn {
+			s.Unlock()
+			return
+		}
+		_, err := conn.Write(message(msgKeepalive, nil))
+		s.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+/**
+Announce routes if the session is established
+*/
+func (s *session) announce(routes []net.IPNet) error {
+	s.Lock()
+	defer s.Unlock()
+	return s.send(routes, true)
+}
+
+/**
+Withdraw routes if the session is established
+*/
+func (s *session) withdraw(routes []net.IPNet) error {
+	s.Lock()
+	defer s.Unlock()
+	return s.send(routes, false)
+}
+
+/**
+Send an update for each route. Routes without a next hop for their address
+family are skipped. A failed write closes the connection so our routes are
+sent again when the session is re-established.
+Note: The session must be locked.
+*/
+func (s *session) send(routes []net.IPNet, announce bool) error {
+	if !s.established {
+		return nil
+	}
+	attrs := pathAttributes(s.bgp.LocalAS, s.peerAS, s.as4)
+	for _, route := range routes {
+		var msg []byte
+		if !announce {
+			msg = withdrawMessage(route)
+		} else if route.IP.To4() != nil && s.nextHop != nil {
+			msg = announceMessage(route, attrs, s.nextHop)
+		} else if route.IP.To4() == nil && s.nextV6 != nil {
+			msg = announceMessage(route, attrs, s.nextV6)
+		} else {
+			continue
+		}
+		if _, err := s.conn.Write(msg); err != nil {
+			log.Warnf("Unable to send a BGP update to %s: %s", s.address, err.Error())
+			s.established = false
+			s.conn.Close()
+			return errors.New("unable to send a bgp update to " + s.address + ": " + err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Syleron/PulseHA/src/netUtils"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+/**
+Networking plugin for cloud VMs where floating IPs are secondary private IPs
+that must be moved between network interfaces through the provider's API.
+Each local interface is mapped to the provider's network interface ID.
+
+Configured with plugins/cloud.json:
+	{
+		"provider": "http",
+		"endpoint": "https://cloud.example.com/v1",
+		"token": "secret",
+		"timeout": 10,
+		"interfaces": {
+			"eth0": "nic-0a1b2c3d"
+		},
+		"configure_interface": true
+	}
+
+When configure_interface is set the IPs are also added to the local interface
+for images that do not pick up secondary IPs themselves.
+
+The plugin deliberately does not report the IPs it holds (ListIPs) so PulseHA
+never reconciles them. Every reconcile pass would otherwise cost a rate limited
+provider API call that moves the IPs again.
+*/
+type Cloud struct {
+	Provider string `json:"provider"`
+	Endpoint string `json:"endpoint"`
+	Token    string `json:"token"`
+	// Seconds to wait for the provider API
+	Timeout            int               `json:"timeout"`
+	Interfaces         map[string]string `json:"interfaces"`
+	ConfigureInterface bool              `json:"configure_interface"`
+	// Make sure we only load our config once
+	once   sync.Once
+	client Provider
+	err    error
+}
+
+/**
+A cloud provider client that moves secondary private IPs between network
+interfaces. Assigning an IP must take it from any other network interface.
+Unassigning an IP that is not assigned must not return an error.
+*/
+type Provider interface {
+	AssignIPs(nic string, ips []string) error
+	UnassignIPs(nic string, ips []string) error
+}
+
+/**
+Available providers keyed by the name used in the config
+*/
+var providers = map[string]func(c *Cloud) (Provider, error){
+	"http": newHTTPProvider,
+}
+
+var PluginNet Cloud
+
+/**
+Note: Required to build the package. Plugins are built with -buildmode=plugin.
+*/
+func main() {}
+
+/**
+Returns the plugin name
+*/
+func (c *Cloud) Name() string {
+	return "Cloud"
+}
+
+/**
+Returns the plugin version
+*/
+func (c *Cloud) Version() float64 {
+	return 1.0
+}
+
+/**
+Load our config from the plugins directory and create our provider client
+*/
+func (c *Cloud) setup() {
+	dir, err := filepath.Abs(filepath.Dir(os.Args[0]))
+	if err != nil {
+		c.err = err
+		return
+	}
+	b, err := ioutil.ReadFile(dir + "/plugins/cloud.json")
+	if err != nil {
+		c.err = errors.New("unable to read cloud config: " + err.Error())
+		return
+	}
+	if err := json.Unmarshal(b, c); err != nil {
+		c.err = errors.New("invalid cloud config: " + err.Error())
+		return
+	}
+	c.configure()
+}
+
+/**
+Create our provider client
+*/
+func (c *Cloud) configure() {
+	if c.Timeout <= 0 {
+		c.Timeout = 10
+	}
+	newProvider, ok := providers[c.Provider]
+	if !ok {
+		c.err = errors.New("unknown cloud provider " + c.Provider)
+		return
+	}
+	c.client, c.err = newProvider(c)
+}
+
+/**
+Returns the provider's network interface ID for a local interface
+*/
+func (c *Cloud) nic(iface string) (string, error) {
+	nic, ok := c.Interfaces[iface]
+	if !ok || nic == "" {
+		return "", errors.New("no cloud network interface configured for " + iface)
+	}
+	return nic, nil
+}
+
+/**
+Returns the ips without their prefix length as the provider expects plain
+secondary IP addresses. Group IPs are stored in CIDR form.
+*/
+func addresses(ips []string) ([]string, error) {
+	var addrs []string
+	for _, ip := range ips {
+		addr, _, err := net.ParseCIDR(ip)
+		if err != nil {
+			if addr = net.ParseIP(ip); addr == nil {
+				return nil, errors.New("invalid ip address " + ip)
+			}
+		}
+		addrs = append(addrs, addr.String())
+	}
+	return addrs, nil
+}
+
+/**
+Returns the provider API timeout
+*/
+func (c *Cloud) timeout() time.Duration {
+	return time.Duration(c.Timeout) * time.Second
+}
+
+/**
+Assign the ips to our network interface, taking them from whichever
+instance currently holds them
+*/
+func (c *Cloud) BringUpIPs(iface string, ips []string) error {
+	c.once.Do(c.setup)
+	if c.err != nil {
+		return c.err
+	}
+	nic, err := c.nic(iface)
+	if err != nil {
+		return err
+	}
+	addrs, err := addresses(ips)
+	if err != nil {
+		return err
+	}
+	if err := c.client.AssignIPs(nic, addrs); err != nil {
+		return err
+	}
+	if !c.ConfigureInterface {
+		return nil
+	}
+	for _, ip := range ips {
+		if err := netUtils.AddAddress(iface, ip); err != nil && !errors.Is(err, netUtils.ErrAddressExists) {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+Unassign the ips from our network interface
+*/
+func (c *Cloud) BringDownIPs(iface string, ips []string) error {
+	c.once.Do(c.setup)
+	if c.err != nil {
+		return c.err
+	}
+	nic, err := c.nic(iface)
+	if err != nil {
+		return err
+	}
+	addrs, err := addresses(ips)
+	if err != nil {
+		return err
+	}
+	if c.ConfigureInterface {
+		for _, ip := range ips {
+			if err := netUtils.DeleteAddress(iface, ip); err != nil && !errors.Is(err, netUtils.ErrAddressNotFound) {
+				return err
+			}
+		}
+	}
+	return c.client.UnassignIPs(nic, addrs)
+}
@@ -0,0 +1,144 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+/**
+Stand-in for a cloud provider API which tracks which network interface
+holds each IP
+*/
+type testCloud struct {
+	owners map[string]string
+	status int
+}
+
+func (tc *testCloud) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if tc.status != 0 {
+		http.Error(w, "quota exceeded", tc.status)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/network-interfaces/"), "/")
+	var body privateIPs
+	if len(parts) != 2 || json.NewDecoder(r.Body).Decode(&body) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	for _, ip := range body.IPs {
+		switch parts[1] {
+		case "assign-private-ips":
+			if tc.owners[ip] != "" && !body.AllowReassignment {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			tc.owners[ip] = parts[0]
+		case "unassign-private-ips":
+			if tc.owners[ip] != parts[0] {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(tc.owners, ip)
+		}
+	}
+}
+
+func newTestCloud(t *testing.T) (*testCloud, *Cloud, func()) {
+	tc := &testCloud{owners: make(map[string]string)}
+	server := httptest.NewServer(tc)
+	c := &Cloud{
+		Provider:   "http",
+		Endpoint:   server.URL,
+		Token:      "secret",
+		Interfaces: map[string]string{"eth0": "nic-1"},
+	}
+	c.once.Do(c.configure)
+	if c.err != nil {
+		t.Fatal(c.err)
+	}
+	return tc, c, server.Close
+}
+
+func TestCloudReassign(t *testing.T) {
+	tc, c, cleanup := newTestCloud(t)
+	defer cleanup()
+	// Another instance currently holds the IP
+	tc.owners["10.0.0.10"] = "nic-2"
+	// Group IPs arrive in CIDR form but the provider only deals in addresses
+	if err := c.BringUpIPs("eth0", []string{"10.0.0.10/24", "10.0.0.11/24"}); err != nil {
+		t.Fatalf("BringUpIPs() returned error: %s", err)
+	}
+	want := map[string]string{"10.0.0.10": "nic-1", "10.0.0.11": "nic-1"}
+	if !reflect.DeepEqual(tc.owners, want) {
+		t.Errorf("owners = %v, want %v", tc.owners, want)
+	}
+	if err := c.BringDownIPs("eth0", []string{"10.0.0.10/24", "10.0.0.11/24"}); err != nil {
+		t.Fatalf("BringDownIPs() returned error: %s", err)
+	}
+	if len(tc.owners) != 0 {
+		t.Errorf("owners = %v, want none", tc.owners)
+	}
+	// Already unassigned
+	if err := c.BringDownIPs("eth0", []string{"10.0.0.10/24"}); err != nil {
+		t.Errorf("BringDownIPs() should succeed when the IP is not assigned: %s", err)
+	}
+}
+
+func TestCloudErrors(t *testing.T) {
+	tc, c, cleanup := newTestCloud(t)
+	defer cleanup()
+	if err := c.BringUpIPs("eth1", []string{"10.0.0.10/24"}); err == nil {
+		t.Error("BringUpIPs() should fail for an interface without a cloud network interface")
+	}
+	if err := c.BringUpIPs("eth0", []string{"not an ip"}); err == nil {
+		t.Error("BringUpIPs() should fail for an invalid ip")
+	}
+	tc.status = http.StatusInternalServerError
+	err := c.BringUpIPs("eth0", []string{"10.0.0.10/24"})
+	if err == nil || !strings.Contains(err.Error(), "quota exceeded") {
+		t.Errorf("BringUpIPs() error %v should contain the provider message", err)
+	}
+}
+
+func TestCloudUnknownProvider(t *testing.T) {
+	c := &Cloud{Provider: "unknown"}
+	c.once.Do(c.configure)
+	if err := c.BringUpIPs("eth0", []string{"10.0.0.10/24"}); err == nil {
+		t.Error("BringUpIPs() should fail for an unknown provider")
+	}
+}
+
+func TestCloudNotReconciled(t *testing.T) {
+	// PulseHA only reconciles plugins that can list the IPs they hold
+	var plugin interface{} = &PluginNet
+	if _, ok := plugin.(interface {
+		ListIPs(iface string) ([]string, error)
+	}); ok {
+		t.Error("the cloud plugin should not be reconciled as each pass would call the provider API")
+	}
+}
@@ -0,0 +1,101 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+/**
+Generic provider for cloud APIs exposing secondary private IPs over REST:
+	POST {endpoint}/network-interfaces/{nic}/assign-private-ips
+	POST {endpoint}/network-interfaces/{nic}/unassign-private-ips
+Both take {"ips": [...]} and authenticate with a bearer token. A 404 when
+unassigning means the IPs are no longer assigned to us.
+*/
+type httpProvider struct {
+	endpoint string
+	token    string
+	client   *http.Client
+}
+
+/**
+Request body for the assign and unassign calls
+*/
+type privateIPs struct {
+	IPs               []string `json:"ips"`
+	AllowReassignment bool     `json:"allow_reassignment,omitempty"`
+}
+
+func newHTTPProvider(c *Cloud) (Provider, error) {
+	if c.Endpoint == "" {
+		return nil, errors.New("no cloud endpoint has been configured")
+	}
+	return &httpProvider{
+		endpoint: strings.TrimRight(c.Endpoint, "/"),
+		token:    c.Token,
+		client:   &http.Client{Timeout: c.timeout()},
+	}, nil
+}
+
+func (p *httpProvider) AssignIPs(nic string, ips []string) error {
+	return p.post(nic, "assign-private-ips", privateIPs{IPs: ips, AllowReassignment: true}, false)
+}
+
+func (p *httpProvider) UnassignIPs(nic string, ips []string) error {
+	return p.post(nic, "unassign-private-ips", privateIPs{IPs: ips}, true)
+}
+
+/**
+Post an action for a network interface and check the response
+*/
+func (p *httpProvider) post(nic string, action string, body privateIPs, allowNotFound bool) error {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", p.endpoint+"/network-interfaces/"+url.PathEscape(nic)+"/"+action, bytes.NewReader(buf))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	if allowNotFound && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	msg, _ := ioutil.ReadAll(resp.Body)
+	return errors.New("cloud provider failed to " + strings.Replace(action, "-", " ", -1) + " on " + nic +
+		" (" + strconv.Itoa(resp.StatusCode) + "): " + strings.TrimSpace(string(msg)))
+}
@@ -0,0 +1,114 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+/**
+Reference fencing plugin that executes a script to fence a node.
+The script is called with the hostname of the node to fence as its only
+argument and must exit with a zero status once the node has been fenced.
+
+Configured with plugins/fence_exec.json:
+	{
+		"script": "/etc/pulseha/fence.sh",
+		"timeout": 30
+	}
+*/
+type FenceExec struct {
+	// Path of the script to execute
+	Script string `json:"script"`
+	// Seconds to wait for the script to complete
+	Timeout int `json:"timeout"`
+	// Make sure we only load our config once
+	once sync.Once
+}
+
+var PluginFence FenceExec
+
+/**
+Note: Required to build the package. Plugins are built with -buildmode=plugin.
+*/
+func main() {}
+
+/**
+Returns the plugin name
+*/
+func (f *FenceExec) Name() string {
+	return "FenceExec"
+}
+
+/**
+Returns the plugin version
+*/
+func (f *FenceExec) Version() float64 {
+	return 1.0
+}
+
+/**
+Load our config from the plugins directory and set any defaults
+*/
+func (f *FenceExec) loadConfig() {
+	dir, err := filepath.Abs(filepath.Dir(os.Args[0]))
+	if err == nil {
+		if b, err := ioutil.ReadFile(dir + "/plugins/fence_exec.json"); err == nil {
+			json.Unmarshal(b, f)
+		}
+		if f.Script == "" {
+			f.Script = dir + "/plugins/fence.sh"
+		}
+	}
+	if f.Timeout <= 0 {
+		f.Timeout = 30
+	}
+}
+
+/**
+Fence a node by executing the configured script
+*/
+func (f *FenceExec) Fence(node string) error {
+	f.once.Do(f.loadConfig)
+	if node == "" {
+		return errors.New("unable to fence as no node was specified")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(f.Timeout)*time.Second)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, f.Script, node).CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return errors.New("fence script timed out while fencing " + node)
+	}
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return errors.New("fence script failed to fence " + node + ": " + msg)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+/**
+Write a stub fence script to a temporary directory
+*/
+func stubScript(t *testing.T, body string) (string, func()) {
+	dir, err := ioutil.TempDir("", "fence_exec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(dir, "fence.sh")
+	if err := ioutil.WriteFile(script, []byte("#!/bin/sh\n"+body+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return script, func() { os.RemoveAll(dir) }
+}
+
+func TestFenceSuccess(t *testing.T) {
+	script, cleanup := stubScript(t, `echo "$1" > "$(dirname "$0")/fenced"`)
+	defer cleanup()
+	f := &FenceExec{Script: script, Timeout: 5}
+	f.once.Do(func() {})
+	if err := f.Fence("node2"); err != nil {
+		t.Fatalf("Fence() returned error: %s", err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(filepath.Dir(script), "fenced"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(string(b)) != "node2" {
+		t.Errorf("fence script was called with %q, want node2", strings.TrimSpace(string(b)))
+	}
+}
+
+func TestFenceFailure(t *testing.T) {
+	script, cleanup := stubScript(t, `echo "pdu unreachable"; exit 1`)
+	defer cleanup()
+	f := &FenceExec{Script: script, Timeout: 5}
+	f.once.Do(func() {})
+	err := f.Fence("node2")
+	if err == nil {
+		t.Fatal("Fence() should fail when the script exits non-zero")
+	}
+	if !strings.Contains(err.Error(), "pdu unreachable") {
+		t.Errorf("Fence() error %q should contain the script output", err)
+	}
+}
+
+func TestFenceTimeout(t *testing.T) {
+	script, cleanup := stubScript(t, `exec sleep 5`)
+	defer cleanup()
+	f := &FenceExec{Script: script, Timeout: 1}
+	f.once.Do(func() {})
+	if err := f.Fence("node2"); err == nil {
+		t.Fatal("Fence() should fail when the script times out")
+	}
+}
@@ -9,6 +9,7 @@ It is generated from these files:
 
 It has these top-level messages:
 	PulseHealthCheck
+	GroupOwner
 	MemberlistMember
 	MemberStatus
 	PulseJoin
@@ -19,14 +20,19 @@ It has these top-level messages:
 	PulseGroupAdd
 	PulseGroupRemove
 	PulseGroupAssign
+	InterfaceSpec
 	PulseGroupUnassign
 	PulseStatus
+	CheckRow
 	StatusRow
+	HeartbeatPath
 	GroupTable
 	GroupRow
 	PulseConfigSync
 	PulsePromote
 	PulseBringIP
+	PulseQuorum
+	PulseMaintenance
 */
 package proto
 
@@ -58,6 +64,7 @@ const (
 	MemberStatus_PASSIVE     MemberStatus_Status = 2
 	MemberStatus_UNAVAILABLE MemberStatus_Status = 3
 	MemberStatus_SUSPICIOUS  MemberStatus_Status = 4
+	MemberStatus_MAINTENANCE MemberStatus_Status = 5
 )
 
 var MemberStatus_Status_name = map[int32]string{
@@ -66,6 +73,7 @@ var MemberStatus_Status_name = map[int32]string{
 	2: "PASSIVE",
 	3: "UNAVAILABLE",
 	4: "SUSPICIOUS",
+	5: "MAINTENANCE",
 }
 var MemberStatus_Status_value = map[string]int32{
 	"ACTIVE":      0,
@@ -73,17 +81,25 @@ var MemberStatus_Status_value = map[string]int32{
 	"PASSIVE":     2,
 	"UNAVAILABLE": 3,
 	"SUSPICIOUS":  4,
+	"MAINTENANCE": 5,
 }
 
 func (x MemberStatus_Status) String() string {
 	return proto1.EnumName(MemberStatus_Status_name, int32(x))
 }
-func (MemberStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2, 0} }
+func (MemberStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3, 0} }
 
 // Pulse Cluster Messages
 type PulseHealthCheck struct {
 	Success    bool                `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
 	Memberlist []*MemberlistMember `protobuf:"bytes,2,rep,name=memberlist" json:"memberlist,omitempty"`
+	Term       uint64              `protobuf:"varint,3,opt,name=term" json:"term,omitempty"`
+	LinkDown   bool                `protobuf:"varint,4,opt,name=link_down,json=linkDown" json:"link_down,omitempty"`
+	Owners     []*GroupOwner       `protobuf:"bytes,5,rep,name=owners" json:"owners,omitempty"`
+	Unhealthy  bool                `protobuf:"varint,6,opt,name=unhealthy" json:"unhealthy,omitempty"`
+	Score      int32               `protobuf:"varint,7,opt,name=score" json:"score,omitempty"`
+	Path       string              `protobuf:"bytes,8,opt,name=path" json:"path,omitempty"`
+	Round      uint64              `protobuf:"varint,9,opt,name=round" json:"round,omitempty"`
 }
 
 func (m *PulseHealthCheck) Reset()                    { *m = PulseHealthCheck{} }
@@ -105,17 +121,94 @@ func (m *PulseHealthCheck) GetMemberlist() []*MemberlistMember {
 	return nil
 }
 
+func (m *PulseHealthCheck) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *PulseHealthCheck) GetLinkDown() bool {
+	if m != nil {
+		return m.LinkDown
+	}
+	return false
+}
+
+func (m *PulseHealthCheck) GetOwners() []*GroupOwner {
+	if m != nil {
+		return m.Owners
+	}
+	return nil
+}
+
+func (m *PulseHealthCheck) GetUnhealthy() bool {
+	if m != nil {
+		return m.Unhealthy
+	}
+	return false
+}
+
+func (m *PulseHealthCheck) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *PulseHealthCheck) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *PulseHealthCheck) GetRound() uint64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+type GroupOwner struct {
+	Group string `protobuf:"bytes,1,opt,name=group" json:"group,omitempty"`
+	Owner string `protobuf:"bytes,2,opt,name=owner" json:"owner,omitempty"`
+}
+
+func (m *GroupOwner) Reset()                    { *m = GroupOwner{} }
+func (m *GroupOwner) String() string            { return proto1.CompactTextString(m) }
+func (*GroupOwner) ProtoMessage()               {}
+func (*GroupOwner) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *GroupOwner) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *GroupOwner) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
 type MemberlistMember struct {
 	Hostname     string              `protobuf:"bytes,1,opt,name=hostname" json:"hostname,omitempty"`
 	Status       MemberStatus_Status `protobuf:"varint,2,opt,name=status,enum=proto.MemberStatus_Status" json:"status,omitempty"`
 	LastReceived string              `protobuf:"bytes,3,opt,name=lastReceived" json:"lastReceived,omitempty"`
 	Latency      string              `protobuf:"bytes,4,opt,name=latency" json:"latency,omitempty"`
+	Term         uint64              `protobuf:"varint,5,opt,name=term" json:"term,omitempty"`
+	LinkDown     bool                `protobuf:"varint,6,opt,name=link_down,json=linkDown" json:"link_down,omitempty"`
+	Unhealthy    bool                `protobuf:"varint,7,opt,name=unhealthy" json:"unhealthy,omitempty"`
+	Score        int32               `protobuf:"varint,8,opt,name=score" json:"score,omitempty"`
 }
 
 func (m *MemberlistMember) Reset()                    { *m = MemberlistMember{} }
 func (m *MemberlistMember) String() string            { return proto1.CompactTextString(m) }
 func (*MemberlistMember) ProtoMessage()               {}
-func (*MemberlistMember) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (*MemberlistMember) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
 
 func (m *MemberlistMember) GetHostname() string {
 	if m != nil {
@@ -145,6 +238,34 @@ func (m *MemberlistMember) GetLatency() string {
 	return ""
 }
 
+func (m *MemberlistMember) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *MemberlistMember) GetLinkDown() bool {
+	if m != nil {
+		return m.LinkDown
+	}
+	return false
+}
+
+func (m *MemberlistMember) GetUnhealthy() bool {
+	if m != nil {
+		return m.Unhealthy
+	}
+	return false
+}
+
+func (m *MemberlistMember) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
 type MemberStatus struct {
 	Status MemberStatus_Status `protobuf:"varint,1,opt,name=status,enum=proto.MemberStatus_Status" json:"status,omitempty"`
 }
@@ -152,7 +273,7 @@ type MemberStatus struct {
 func (m *MemberStatus) Reset()                    { *m = MemberStatus{} }
 func (m *MemberStatus) String() string            { return proto1.CompactTextString(m) }
 func (*MemberStatus) ProtoMessage()               {}
-func (*MemberStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*MemberStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
 func (m *MemberStatus) GetStatus() MemberStatus_Status {
 	if m != nil {
@@ -176,7 +297,7 @@ type PulseJoin struct {
 func (m *PulseJoin) Reset()                    { *m = PulseJoin{} }
 func (m *PulseJoin) String() string            { return proto1.CompactTextString(m) }
 func (*PulseJoin) ProtoMessage()               {}
-func (*PulseJoin) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*PulseJoin) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *PulseJoin) GetSuccess() bool {
 	if m != nil {
@@ -251,7 +372,7 @@ type PulseLeave struct {
 func (m *PulseLeave) Reset()                    { *m = PulseLeave{} }
 func (m *PulseLeave) String() string            { return proto1.CompactTextString(m) }
 func (*PulseLeave) ProtoMessage()               {}
-func (*PulseLeave) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*PulseLeave) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *PulseLeave) GetSuccess() bool {
 	if m != nil {
@@ -291,7 +412,7 @@ type PulseCreate struct {
 func (m *PulseCreate) Reset()                    { *m = PulseCreate{} }
 func (m *PulseCreate) String() string            { return proto1.CompactTextString(m) }
 func (*PulseCreate) ProtoMessage()               {}
-func (*PulseCreate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*PulseCreate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *PulseCreate) GetSuccess() bool {
 	if m != nil {
@@ -330,7 +451,7 @@ type PulseGroupNew struct {
 func (m *PulseGroupNew) Reset()                    { *m = PulseGroupNew{} }
 func (m *PulseGroupNew) String() string            { return proto1.CompactTextString(m) }
 func (*PulseGroupNew) ProtoMessage()               {}
-func (*PulseGroupNew) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*PulseGroupNew) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *PulseGroupNew) GetSuccess() bool {
 	if m != nil {
@@ -355,7 +476,7 @@ type PulseGroupDelete struct {
 func (m *PulseGroupDelete) Reset()                    { *m = PulseGroupDelete{} }
 func (m *PulseGroupDelete) String() string            { return proto1.CompactTextString(m) }
 func (*PulseGroupDelete) ProtoMessage()               {}
-func (*PulseGroupDelete) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*PulseGroupDelete) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *PulseGroupDelete) GetSuccess() bool {
 	if m != nil {
@@ -388,7 +509,7 @@ type PulseGroupAdd struct {
 func (m *PulseGroupAdd) Reset()                    { *m = PulseGroupAdd{} }
 func (m *PulseGroupAdd) String() string            { return proto1.CompactTextString(m) }
 func (*PulseGroupAdd) ProtoMessage()               {}
-func (*PulseGroupAdd) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*PulseGroupAdd) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *PulseGroupAdd) GetSuccess() bool {
 	if m != nil {
@@ -428,7 +549,7 @@ type PulseGroupRemove struct {
 func (m *PulseGroupRemove) Reset()                    { *m = PulseGroupRemove{} }
 func (m *PulseGroupRemove) String() string            { return proto1.CompactTextString(m) }
 func (*PulseGroupRemove) ProtoMessage()               {}
-func (*PulseGroupRemove) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*PulseGroupRemove) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *PulseGroupRemove) GetSuccess() bool {
 	if m != nil {
@@ -459,17 +580,18 @@ func (m *PulseGroupRemove) GetIps() []string {
 }
 
 type PulseGroupAssign struct {
-	Success   bool   `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
-	Message   string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
-	Group     string `protobuf:"bytes,3,opt,name=group" json:"group,omitempty"`
-	Interface string `protobuf:"bytes,4,opt,name=interface" json:"interface,omitempty"`
-	Node      string `protobuf:"bytes,5,opt,name=node" json:"node,omitempty"`
+	Success   bool           `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
+	Message   string         `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+	Group     string         `protobuf:"bytes,3,opt,name=group" json:"group,omitempty"`
+	Interface string         `protobuf:"bytes,4,opt,name=interface" json:"interface,omitempty"`
+	Node      string         `protobuf:"bytes,5,opt,name=node" json:"node,omitempty"`
+	Spec      *InterfaceSpec `protobuf:"bytes,6,opt,name=spec" json:"spec,omitempty"`
 }
 
 func (m *PulseGroupAssign) Reset()                    { *m = PulseGroupAssign{} }
 func (m *PulseGroupAssign) String() string            { return proto1.CompactTextString(m) }
 func (*PulseGroupAssign) ProtoMessage()               {}
-func (*PulseGroupAssign) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*PulseGroupAssign) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *PulseGroupAssign) GetSuccess() bool {
 	if m != nil {
@@ -506,6 +628,62 @@ func (m *PulseGroupAssign) GetNode() string {
 	return ""
 }
 
+func (m *PulseGroupAssign) GetSpec() *InterfaceSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+// An interface created by PulseHA for a group assignment
+type InterfaceSpec struct {
+	Type   string   `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Parent string   `protobuf:"bytes,2,opt,name=parent" json:"parent,omitempty"`
+	Vlan   int32    `protobuf:"varint,3,opt,name=vlan" json:"vlan,omitempty"`
+	Slaves []string `protobuf:"bytes,4,rep,name=slaves" json:"slaves,omitempty"`
+	Mode   string   `protobuf:"bytes,5,opt,name=mode" json:"mode,omitempty"`
+}
+
+func (m *InterfaceSpec) Reset()                    { *m = InterfaceSpec{} }
+func (m *InterfaceSpec) String() string            { return proto1.CompactTextString(m) }
+func (*InterfaceSpec) ProtoMessage()               {}
+func (*InterfaceSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *InterfaceSpec) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *InterfaceSpec) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *InterfaceSpec) GetVlan() int32 {
+	if m != nil {
+		return m.Vlan
+	}
+	return 0
+}
+
+func (m *InterfaceSpec) GetSlaves() []string {
+	if m != nil {
+		return m.Slaves
+	}
+	return nil
+}
+
+func (m *InterfaceSpec) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
 type PulseGroupUnassign struct {
 	Success   bool   `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
 	Message   string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
@@ -517,7 +695,7 @@ type PulseGroupUnassign struct {
 func (m *PulseGroupUnassign) Reset()                    { *m = PulseGroupUnassign{} }
 func (m *PulseGroupUnassign) String() string            { return proto1.CompactTextString(m) }
 func (*PulseGroupUnassign) ProtoMessage()               {}
-func (*PulseGroupUnassign) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*PulseGroupUnassign) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *PulseGroupUnassign) GetSuccess() bool {
 	if m != nil {
@@ -555,15 +733,21 @@ func (m *PulseGroupUnassign) GetNode() string {
 }
 
 type PulseStatus struct {
-	Success bool         `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
-	Message string       `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
-	Row     []*StatusRow `protobuf:"bytes,3,rep,name=row" json:"row,omitempty"`
+	Success     bool          `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
+	Message     string        `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+	Row         []*StatusRow  `protobuf:"bytes,3,rep,name=row" json:"row,omitempty"`
+	Maintenance bool          `protobuf:"varint,4,opt,name=maintenance" json:"maintenance,omitempty"`
+	Drift       []string      `protobuf:"bytes,5,rep,name=drift" json:"drift,omitempty"`
+	Owners      []*GroupOwner `protobuf:"bytes,6,rep,name=owners" json:"owners,omitempty"`
+	Checks      []*CheckRow   `protobuf:"bytes,7,rep,name=checks" json:"checks,omitempty"`
+	Damped      bool          `protobuf:"varint,8,opt,name=damped" json:"damped,omitempty"`
+	Flaps       []string      `protobuf:"bytes,9,rep,name=flaps" json:"flaps,omitempty"`
 }
 
 func (m *PulseStatus) Reset()                    { *m = PulseStatus{} }
 func (m *PulseStatus) String() string            { return proto1.CompactTextString(m) }
 func (*PulseStatus) ProtoMessage()               {}
-func (*PulseStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*PulseStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *PulseStatus) GetSuccess() bool {
 	if m != nil {
@@ -586,18 +770,112 @@ func (m *PulseStatus) GetRow() []*StatusRow {
 	return nil
 }
 
+func (m *PulseStatus) GetMaintenance() bool {
+	if m != nil {
+		return m.Maintenance
+	}
+	return false
+}
+
+func (m *PulseStatus) GetDrift() []string {
+	if m != nil {
+		return m.Drift
+	}
+	return nil
+}
+
+func (m *PulseStatus) GetOwners() []*GroupOwner {
+	if m != nil {
+		return m.Owners
+	}
+	return nil
+}
+
+func (m *PulseStatus) GetChecks() []*CheckRow {
+	if m != nil {
+		return m.Checks
+	}
+	return nil
+}
+
+func (m *PulseStatus) GetDamped() bool {
+	if m != nil {
+		return m.Damped
+	}
+	return false
+}
+
+func (m *PulseStatus) GetFlaps() []string {
+	if m != nil {
+		return m.Flaps
+	}
+	return nil
+}
+
+// The state of one of the local node's health checks
+type CheckRow struct {
+	Name      string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Type      string `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	Healthy   bool   `protobuf:"varint,3,opt,name=healthy" json:"healthy,omitempty"`
+	Message   string `protobuf:"bytes,4,opt,name=message" json:"message,omitempty"`
+	LastCheck string `protobuf:"bytes,5,opt,name=last_check,json=lastCheck" json:"last_check,omitempty"`
+}
+
+func (m *CheckRow) Reset()                    { *m = CheckRow{} }
+func (m *CheckRow) String() string            { return proto1.CompactTextString(m) }
+func (*CheckRow) ProtoMessage()               {}
+func (*CheckRow) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *CheckRow) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CheckRow) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *CheckRow) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *CheckRow) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *CheckRow) GetLastCheck() string {
+	if m != nil {
+		return m.LastCheck
+	}
+	return ""
+}
+
 type StatusRow struct {
 	Hostname     string              `protobuf:"bytes,1,opt,name=hostname" json:"hostname,omitempty"`
 	Ip           string              `protobuf:"bytes,2,opt,name=ip" json:"ip,omitempty"`
 	Latency      string              `protobuf:"bytes,3,opt,name=latency" json:"latency,omitempty"`
 	Status       MemberStatus_Status `protobuf:"varint,4,opt,name=status,enum=proto.MemberStatus_Status" json:"status,omitempty"`
 	LastReceived string              `protobuf:"bytes,5,opt,name=lastReceived" json:"lastReceived,omitempty"`
+	Maintenance  bool                `protobuf:"varint,6,opt,name=maintenance" json:"maintenance,omitempty"`
+	Score        int32               `protobuf:"varint,7,opt,name=score" json:"score,omitempty"`
+	Paths        []*HeartbeatPath    `protobuf:"bytes,8,rep,name=paths" json:"paths,omitempty"`
 }
 
 func (m *StatusRow) Reset()                    { *m = StatusRow{} }
 func (m *StatusRow) String() string            { return proto1.CompactTextString(m) }
 func (*StatusRow) ProtoMessage()               {}
-func (*StatusRow) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*StatusRow) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *StatusRow) GetHostname() string {
 	if m != nil {
@@ -634,6 +912,68 @@ func (m *StatusRow) GetLastReceived() string {
 	return ""
 }
 
+func (m *StatusRow) GetMaintenance() bool {
+	if m != nil {
+		return m.Maintenance
+	}
+	return false
+}
+
+func (m *StatusRow) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *StatusRow) GetPaths() []*HeartbeatPath {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+// The state of one of the heartbeat paths to a node
+type HeartbeatPath struct {
+	Address      string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	Up           bool   `protobuf:"varint,2,opt,name=up" json:"up,omitempty"`
+	Latency      string `protobuf:"bytes,3,opt,name=latency" json:"latency,omitempty"`
+	LastReceived string `protobuf:"bytes,4,opt,name=last_received,json=lastReceived" json:"last_received,omitempty"`
+}
+
+func (m *HeartbeatPath) Reset()                    { *m = HeartbeatPath{} }
+func (m *HeartbeatPath) String() string            { return proto1.CompactTextString(m) }
+func (*HeartbeatPath) ProtoMessage()               {}
+func (*HeartbeatPath) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *HeartbeatPath) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *HeartbeatPath) GetUp() bool {
+	if m != nil {
+		return m.Up
+	}
+	return false
+}
+
+func (m *HeartbeatPath) GetLatency() string {
+	if m != nil {
+		return m.Latency
+	}
+	return ""
+}
+
+func (m *HeartbeatPath) GetLastReceived() string {
+	if m != nil {
+		return m.LastReceived
+	}
+	return ""
+}
+
 type GroupTable struct {
 	Success bool        `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
 	Message string      `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
@@ -643,7 +983,7 @@ type GroupTable struct {
 func (m *GroupTable) Reset()                    { *m = GroupTable{} }
 func (m *GroupTable) String() string            { return proto1.CompactTextString(m) }
 func (*GroupTable) ProtoMessage()               {}
-func (*GroupTable) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*GroupTable) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *GroupTable) GetSuccess() bool {
 	if m != nil {
@@ -671,12 +1011,14 @@ type GroupRow struct {
 	Ip         []string `protobuf:"bytes,2,rep,name=ip" json:"ip,omitempty"`
 	Nodes      []string `protobuf:"bytes,3,rep,name=nodes" json:"nodes,omitempty"`
 	Interfaces []string `protobuf:"bytes,4,rep,name=interfaces" json:"interfaces,omitempty"`
+	Owner      string   `protobuf:"bytes,5,opt,name=owner" json:"owner,omitempty"`
+	Routes     []string `protobuf:"bytes,6,rep,name=routes" json:"routes,omitempty"`
 }
 
 func (m *GroupRow) Reset()                    { *m = GroupRow{} }
 func (m *GroupRow) String() string            { return proto1.CompactTextString(m) }
 func (*GroupRow) ProtoMessage()               {}
-func (*GroupRow) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*GroupRow) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *GroupRow) GetName() string {
 	if m != nil {
@@ -706,6 +1048,20 @@ func (m *GroupRow) GetInterfaces() []string {
 	return nil
 }
 
+func (m *GroupRow) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *GroupRow) GetRoutes() []string {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
 type PulseConfigSync struct {
 	Success    bool   `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
 	Message    string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
@@ -716,7 +1072,7 @@ type PulseConfigSync struct {
 func (m *PulseConfigSync) Reset()                    { *m = PulseConfigSync{} }
 func (m *PulseConfigSync) String() string            { return proto1.CompactTextString(m) }
 func (*PulseConfigSync) ProtoMessage()               {}
-func (*PulseConfigSync) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*PulseConfigSync) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *PulseConfigSync) GetSuccess() bool {
 	if m != nil {
@@ -750,12 +1106,14 @@ type PulsePromote struct {
 	Success bool   `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
 	Message string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
 	Member  string `protobuf:"bytes,3,opt,name=member" json:"member,omitempty"`
+	Term    uint64 `protobuf:"varint,4,opt,name=term" json:"term,omitempty"`
+	Group   string `protobuf:"bytes,5,opt,name=group" json:"group,omitempty"`
 }
 
 func (m *PulsePromote) Reset()                    { *m = PulsePromote{} }
 func (m *PulsePromote) String() string            { return proto1.CompactTextString(m) }
 func (*PulsePromote) ProtoMessage()               {}
-func (*PulsePromote) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*PulsePromote) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *PulsePromote) GetSuccess() bool {
 	if m != nil {
@@ -778,6 +1136,20 @@ func (m *PulsePromote) GetMember() string {
 	return ""
 }
 
+func (m *PulsePromote) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *PulsePromote) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
 type PulseBringIP struct {
 	Success bool     `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
 	Message string   `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
@@ -788,7 +1160,7 @@ type PulseBringIP struct {
 func (m *PulseBringIP) Reset()                    { *m = PulseBringIP{} }
 func (m *PulseBringIP) String() string            { return proto1.CompactTextString(m) }
 func (*PulseBringIP) ProtoMessage()               {}
-func (*PulseBringIP) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*PulseBringIP) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *PulseBringIP) GetSuccess() bool {
 	if m != nil {
@@ -818,8 +1190,97 @@ func (m *PulseBringIP) GetIps() []string {
 	return nil
 }
 
+type PulseQuorum struct {
+	Success     bool   `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
+	Message     string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+	Candidate   string `protobuf:"bytes,3,opt,name=candidate" json:"candidate,omitempty"`
+	Active      string `protobuf:"bytes,4,opt,name=active" json:"active,omitempty"`
+	Unreachable bool   `protobuf:"varint,5,opt,name=unreachable" json:"unreachable,omitempty"`
+}
+
+func (m *PulseQuorum) Reset()                    { *m = PulseQuorum{} }
+func (m *PulseQuorum) String() string            { return proto1.CompactTextString(m) }
+func (*PulseQuorum) ProtoMessage()               {}
+func (*PulseQuorum) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *PulseQuorum) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *PulseQuorum) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *PulseQuorum) GetCandidate() string {
+	if m != nil {
+		return m.Candidate
+	}
+	return ""
+}
+
+func (m *PulseQuorum) GetActive() string {
+	if m != nil {
+		return m.Active
+	}
+	return ""
+}
+
+func (m *PulseQuorum) GetUnreachable() bool {
+	if m != nil {
+		return m.Unreachable
+	}
+	return false
+}
+
+type PulseMaintenance struct {
+	Success bool   `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+	Enable  bool   `protobuf:"varint,3,opt,name=enable" json:"enable,omitempty"`
+	Node    string `protobuf:"bytes,4,opt,name=node" json:"node,omitempty"`
+}
+
+func (m *PulseMaintenance) Reset()                    { *m = PulseMaintenance{} }
+func (m *PulseMaintenance) String() string            { return proto1.CompactTextString(m) }
+func (*PulseMaintenance) ProtoMessage()               {}
+func (*PulseMaintenance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *PulseMaintenance) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *PulseMaintenance) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *PulseMaintenance) GetEnable() bool {
+	if m != nil {
+		return m.Enable
+	}
+	return false
+}
+
+func (m *PulseMaintenance) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
 func init() {
 	proto1.RegisterType((*PulseHealthCheck)(nil), "proto.PulseHealthCheck")
+	proto1.RegisterType((*GroupOwner)(nil), "proto.GroupOwner")
 	proto1.RegisterType((*MemberlistMember)(nil), "proto.MemberlistMember")
 	proto1.RegisterType((*MemberStatus)(nil), "proto.MemberStatus")
 	proto1.RegisterType((*PulseJoin)(nil), "proto.PulseJoin")
@@ -830,14 +1291,19 @@ func init() {
 	proto1.RegisterType((*PulseGroupAdd)(nil), "proto.PulseGroupAdd")
 	proto1.RegisterType((*PulseGroupRemove)(nil), "proto.PulseGroupRemove")
 	proto1.RegisterType((*PulseGroupAssign)(nil), "proto.PulseGroupAssign")
+	proto1.RegisterType((*InterfaceSpec)(nil), "proto.InterfaceSpec")
 	proto1.RegisterType((*PulseGroupUnassign)(nil), "proto.PulseGroupUnassign")
 	proto1.RegisterType((*PulseStatus)(nil), "proto.PulseStatus")
+	proto1.RegisterType((*CheckRow)(nil), "proto.CheckRow")
 	proto1.RegisterType((*StatusRow)(nil), "proto.StatusRow")
+	proto1.RegisterType((*HeartbeatPath)(nil), "proto.HeartbeatPath")
 	proto1.RegisterType((*GroupTable)(nil), "proto.GroupTable")
 	proto1.RegisterType((*GroupRow)(nil), "proto.GroupRow")
 	proto1.RegisterType((*PulseConfigSync)(nil), "proto.PulseConfigSync")
 	proto1.RegisterType((*PulsePromote)(nil), "proto.PulsePromote")
 	proto1.RegisterType((*PulseBringIP)(nil), "proto.PulseBringIP")
+	proto1.RegisterType((*PulseQuorum)(nil), "proto.PulseQuorum")
+	proto1.RegisterType((*PulseMaintenance)(nil), "proto.PulseMaintenance")
 	proto1.RegisterEnum("proto.MemberStatus_Status", MemberStatus_Status_name, MemberStatus_Status_value)
 }
 
@@ -876,6 +1342,8 @@ type CLIClient interface {
 	Status(ctx context.Context, in *PulseStatus, opts ...grpc.CallOption) (*PulseStatus, error)
 	// Promote a member
 	Promote(ctx context.Context, in *PulsePromote, opts ...grpc.CallOption) (*PulsePromote, error)
+	// Toggle maintenance mode for a node or the cluster
+	Maintenance(ctx context.Context, in *PulseMaintenance, opts ...grpc.CallOption) (*PulseMaintenance, error)
 }
 
 type cLIClient struct {
@@ -994,6 +1462,15 @@ func (c *cLIClient) Promote(ctx context.Context, in *PulsePromote, opts ...grpc.
 	return out, nil
 }
 
+func (c *cLIClient) Maintenance(ctx context.Context, in *PulseMaintenance, opts ...grpc.CallOption) (*PulseMaintenance, error) {
+	out := new(PulseMaintenance)
+	err := grpc.Invoke(ctx, "/proto.CLI/Maintenance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for CLI service
 
 type CLIServer interface {
@@ -1021,6 +1498,8 @@ type CLIServer interface {
 	Status(context.Context, *PulseStatus) (*PulseStatus, error)
 	// Promote a member
 	Promote(context.Context, *PulsePromote) (*PulsePromote, error)
+	// Toggle maintenance mode for a node or the cluster
+	Maintenance(context.Context, *PulseMaintenance) (*PulseMaintenance, error)
 }
 
 func RegisterCLIServer(s *grpc.Server, srv CLIServer) {
@@ -1243,6 +1722,24 @@ func _CLI_Promote_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CLI_Maintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PulseMaintenance)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CLIServer).Maintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.CLI/Maintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CLIServer).Maintenance(ctx, req.(*PulseMaintenance))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _CLI_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.CLI",
 	HandlerType: (*CLIServer)(nil),
@@ -1295,6 +1792,10 @@ var _CLI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Promote",
 			Handler:    _CLI_Promote_Handler,
 		},
+		{
+			MethodName: "Maintenance",
+			Handler:    _CLI_Maintenance_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/pulse.proto",
@@ -1319,6 +1820,8 @@ type ServerClient interface {
 	BringUpIP(ctx context.Context, in *PulseBringIP, opts ...grpc.CallOption) (*PulseBringIP, error)
 	// Bring down IP
 	BringDownIP(ctx context.Context, in *PulseBringIP, opts ...grpc.CallOption) (*PulseBringIP, error)
+	// Request a failover quorum vote
+	QuorumVote(ctx context.Context, in *PulseQuorum, opts ...grpc.CallOption) (*PulseQuorum, error)
 }
 
 type serverClient struct {
@@ -1401,6 +1904,15 @@ func (c *serverClient) BringDownIP(ctx context.Context, in *PulseBringIP, opts .
 	return out, nil
 }
 
+func (c *serverClient) QuorumVote(ctx context.Context, in *PulseQuorum, opts ...grpc.CallOption) (*PulseQuorum, error) {
+	out := new(PulseQuorum)
+	err := grpc.Invoke(ctx, "/proto.Server/QuorumVote", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Server service
 
 type ServerServer interface {
@@ -1420,6 +1932,8 @@ type ServerServer interface {
 	BringUpIP(context.Context, *PulseBringIP) (*PulseBringIP, error)
 	// Bring down IP
 	BringDownIP(context.Context, *PulseBringIP) (*PulseBringIP, error)
+	// Request a failover quorum vote
+	QuorumVote(context.Context, *PulseQuorum) (*PulseQuorum, error)
 }
 
 func RegisterServerServer(s *grpc.Server, srv ServerServer) {
@@ -1570,6 +2084,24 @@ func _Server_BringDownIP_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Server_QuorumVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PulseQuorum)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerServer).QuorumVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Server/QuorumVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerServer).QuorumVote(ctx, req.(*PulseQuorum))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Server_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.Server",
 	HandlerType: (*ServerServer)(nil),
@@ -1606,6 +2138,10 @@ var _Server_serviceDesc = grpc.ServiceDesc{
 			MethodName: "BringDownIP",
 			Handler:    _Server_BringDownIP_Handler,
 		},
+		{
+			MethodName: "QuorumVote",
+			Handler:    _Server_QuorumVote_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/pulse.proto",
@@ -1614,67 +2150,100 @@ var _Server_serviceDesc = grpc.ServiceDesc{
 func init() { proto1.RegisterFile("proto/pulse.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 989 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0x5f, 0x6f, 0xe3, 0x44,
-	0x10, 0xc7, 0x71, 0xe2, 0xc4, 0x93, 0x5e, 0x9b, 0x5b, 0xaa, 0xd6, 0x18, 0x84, 0xc2, 0x3e, 0x45,
-	0x48, 0x14, 0xc8, 0x09, 0x8e, 0x07, 0x24, 0xe4, 0xcb, 0x55, 0x87, 0x51, 0xae, 0x17, 0x39, 0x97,
-	0x3e, 0xdc, 0x0b, 0x72, 0x9d, 0xbd, 0xd4, 0x9c, 0x63, 0x5b, 0xb6, 0x9b, 0xa8, 0x12, 0x7c, 0x05,
-	0x84, 0xc4, 0x17, 0xe0, 0x15, 0x3e, 0x05, 0x5f, 0x88, 0xef, 0x80, 0x3c, 0xbb, 0x76, 0x37, 0x75,
-	0x52, 0xa8, 0xc5, 0x9f, 0xa7, 0xec, 0xfc, 0x7e, 0xeb, 0x9d, 0xd9, 0x99, 0x9d, 0xdf, 0x04, 0x1e,
-	0xc6, 0x49, 0x94, 0x45, 0x1f, 0xc7, 0x57, 0x41, 0xca, 0x4e, 0x70, 0x4d, 0x5a, 0xf8, 0x43, 0x19,
-	0xf4, 0x26, 0x39, 0xfa, 0x35, 0x73, 0x83, 0xec, 0x72, 0x74, 0xc9, 0xbc, 0x37, 0xc4, 0x80, 0x76,
-	0x7a, 0xe5, 0x79, 0x2c, 0x4d, 0x0d, 0xa5, 0xaf, 0x0c, 0x3a, 0x4e, 0x61, 0x92, 0xc7, 0x00, 0x4b,
-	0xb6, 0xbc, 0x60, 0x49, 0xe0, 0xa7, 0x99, 0xd1, 0xe8, 0xab, 0x83, 0xee, 0xf0, 0x98, 0x1f, 0x78,
-	0xf2, 0xbc, 0x24, 0xf8, 0xca, 0x91, 0xb6, 0xd2, 0x5f, 0x14, 0xe8, 0xdd, 0xde, 0x40, 0x4c, 0xe8,
-	0x5c, 0x46, 0x69, 0x16, 0xba, 0x4b, 0x86, 0x8e, 0x74, 0xa7, 0xb4, 0xc9, 0x10, 0xb4, 0x34, 0x73,
-	0xb3, 0xab, 0xd4, 0x68, 0xf4, 0x95, 0xc1, 0xfe, 0xd0, 0xdc, 0xf0, 0x32, 0x45, 0xea, 0x84, 0xff,
-	0x38, 0x62, 0x27, 0xa1, 0xb0, 0x17, 0xb8, 0x69, 0xe6, 0x30, 0x8f, 0xf9, 0x2b, 0x36, 0x37, 0x54,
-	0x3c, 0x73, 0x03, 0xcb, 0xef, 0x16, 0xb8, 0x19, 0x0b, 0xbd, 0x6b, 0xa3, 0x89, 0x74, 0x61, 0xd2,
-	0x9f, 0x15, 0xd8, 0x93, 0x4f, 0x97, 0x42, 0x50, 0xfe, 0x6e, 0x08, 0xf4, 0x05, 0x68, 0xe2, 0x6b,
-	0x00, 0xcd, 0x1a, 0xbd, 0xb4, 0xcf, 0x4f, 0x7b, 0x6f, 0x91, 0x2e, 0xb4, 0xc7, 0xa7, 0xd6, 0xb9,
-	0x7d, 0xf6, 0xac, 0xa7, 0xe4, 0xc6, 0xc4, 0x9a, 0x4e, 0x73, 0xa6, 0x41, 0x0e, 0xa0, 0x3b, 0x3b,
-	0xb3, 0xce, 0x2d, 0x7b, 0x6c, 0x3d, 0x19, 0x9f, 0xf6, 0x54, 0xb2, 0x0f, 0x30, 0x9d, 0x4d, 0x27,
-	0xf6, 0xc8, 0x7e, 0x31, 0x9b, 0xf6, 0x9a, 0xf4, 0x0f, 0x05, 0x74, 0x2c, 0xd0, 0x37, 0x91, 0x1f,
-	0xde, 0x51, 0x19, 0x03, 0xda, 0x4b, 0x96, 0xa6, 0xee, 0x82, 0x61, 0xc2, 0x74, 0xa7, 0x30, 0xc9,
-	0x31, 0xb4, 0x2f, 0xfc, 0x70, 0xfe, 0xad, 0x1f, 0x8b, 0x84, 0x68, 0xb9, 0x69, 0xc7, 0xe4, 0x5d,
-	0xd0, 0x91, 0x88, 0xa3, 0x24, 0x13, 0xc9, 0xe8, 0xe4, 0xc0, 0x24, 0x4a, 0x32, 0xb2, 0x0f, 0x0d,
-	0x3f, 0x36, 0x5a, 0x88, 0x36, 0xfc, 0x98, 0x10, 0x68, 0xe2, 0x3e, 0x0d, 0x11, 0x5c, 0x6f, 0xd4,
-	0xaf, 0x7d, 0xab, 0x7e, 0xef, 0x03, 0x24, 0x2c, 0x0e, 0x7c, 0xcf, 0xcd, 0xd8, 0xdc, 0xe8, 0x60,
-	0xb0, 0x12, 0x42, 0x8e, 0x40, 0xf3, 0xa2, 0xf0, 0xb5, 0xbf, 0x30, 0xf4, 0xbe, 0x32, 0xd8, 0x73,
-	0x84, 0x45, 0xbf, 0x07, 0xc0, 0xeb, 0x8e, 0x99, 0xbb, 0x62, 0xb5, 0xee, 0x2b, 0x47, 0xa5, 0xde,
-	0x19, 0x55, 0xf3, 0x76, 0x54, 0x74, 0x0d, 0x5d, 0xf4, 0x3e, 0x4a, 0x98, 0x9b, 0xb1, 0xff, 0x2e,
-	0xdd, 0x74, 0x04, 0x0f, 0xd0, 0xf1, 0xb3, 0x24, 0xba, 0x8a, 0xcf, 0xd8, 0xba, 0x8e, 0x6b, 0xfa,
-	0x4a, 0xf4, 0x32, 0x1e, 0xf2, 0x94, 0x05, 0xac, 0xe6, 0x15, 0x08, 0x34, 0xa5, 0xec, 0xe1, 0x9a,
-	0xfa, 0x72, 0x80, 0xd6, 0x7c, 0xfe, 0x4f, 0x1d, 0x4c, 0x7a, 0xa0, 0xfa, 0x71, 0x6a, 0x34, 0xfb,
-	0xea, 0x40, 0x77, 0xf2, 0x25, 0x0d, 0xe4, 0x6b, 0x38, 0x6c, 0x19, 0xad, 0xd8, 0xbf, 0xe8, 0xed,
-	0x47, 0x45, 0x76, 0x67, 0xa5, 0xa9, 0xbf, 0xa8, 0xd7, 0x67, 0x87, 0xd0, 0x5a, 0xe4, 0x47, 0x08,
-	0x7f, 0xdc, 0x20, 0xef, 0x81, 0xee, 0x87, 0x19, 0x4b, 0x5e, 0xbb, 0x1e, 0x13, 0x55, 0xbf, 0x01,
-	0x30, 0xc4, 0x68, 0xce, 0x44, 0x9f, 0xe1, 0x9a, 0xfe, 0xa4, 0x00, 0xb9, 0x09, 0x68, 0x16, 0xba,
-	0xff, 0x7f, 0x48, 0x4c, 0xb4, 0x85, 0x90, 0xb6, 0x3a, 0xa1, 0x50, 0x50, 0x93, 0x68, 0x6d, 0xa8,
-	0x38, 0x32, 0x7a, 0x42, 0x49, 0x85, 0x78, 0x46, 0x6b, 0x27, 0x27, 0xe9, 0xaf, 0x0a, 0xe8, 0x25,
-	0x74, 0xe7, 0x74, 0xe0, 0xea, 0xd4, 0x28, 0xd5, 0x49, 0x52, 0x75, 0x75, 0x43, 0xd5, 0x25, 0x11,
-	0x6f, 0xd6, 0x9e, 0x23, 0xad, 0xea, 0x1c, 0xa1, 0x1e, 0x00, 0xd6, 0xe7, 0xa5, 0x7b, 0x11, 0xd4,
-	0x7b, 0x9e, 0x1f, 0xc8, 0x19, 0x39, 0x10, 0x61, 0xf1, 0x37, 0x5f, 0x24, 0x64, 0x0e, 0x9d, 0x02,
-	0x28, 0x5f, 0xb3, 0x22, 0xbd, 0xe6, 0x22, 0x0d, 0xaa, 0x48, 0xc3, 0x21, 0xb4, 0xf2, 0x7a, 0xa5,
-	0x78, 0xa8, 0xee, 0x70, 0x23, 0x17, 0xbd, 0xb2, 0xbc, 0xc5, 0xd3, 0x97, 0x10, 0xfa, 0x03, 0x1c,
-	0x70, 0xd1, 0x43, 0x05, 0x9e, 0x5e, 0x87, 0x5e, 0xad, 0xfb, 0xdc, 0x28, 0xba, 0x2a, 0x2b, 0xfa,
-	0x5f, 0x6a, 0xee, 0x2b, 0xd8, 0x43, 0xf7, 0x93, 0x24, 0x5a, 0x46, 0x35, 0x15, 0xeb, 0x08, 0x34,
-	0xfe, 0x67, 0xa3, 0xd0, 0x5c, 0x6e, 0xd1, 0xef, 0xc4, 0xd9, 0x4f, 0x12, 0x3f, 0x5c, 0xd8, 0x93,
-	0xba, 0x4d, 0xe4, 0x63, 0xab, 0x88, 0x26, 0x42, 0xa3, 0x2a, 0x24, 0xc3, 0xdf, 0x5a, 0xa0, 0x8e,
-	0xc6, 0x36, 0xf9, 0x10, 0x9a, 0x38, 0xab, 0x8b, 0x47, 0x5e, 0x4e, 0x6f, 0xb3, 0x82, 0x90, 0x8f,
-	0xa0, 0xc5, 0x07, 0xdd, 0x43, 0x99, 0x42, 0xc8, 0xac, 0x42, 0xe4, 0x13, 0xd0, 0xc4, 0x64, 0x22,
-	0x32, 0xc9, 0x31, 0x73, 0x0b, 0x46, 0x3e, 0x87, 0xce, 0x19, 0x5b, 0xe3, 0x23, 0x22, 0x87, 0x32,
-	0x5f, 0x0c, 0x1a, 0x73, 0x2b, 0x4a, 0xbe, 0x82, 0x2e, 0x1f, 0x20, 0xfc, 0xd3, 0xe3, 0xca, 0x26,
-	0xce, 0x9a, 0xbb, 0x08, 0xf2, 0x85, 0xe8, 0x0f, 0x7b, 0x92, 0x0f, 0x8b, 0xaa, 0x13, 0x6b, 0x3e,
-	0x37, 0xb7, 0xa2, 0xc4, 0x82, 0x07, 0xe2, 0x4b, 0xa1, 0xfd, 0x55, 0x1f, 0x9c, 0x30, 0x77, 0x11,
-	0x79, 0xf4, 0xb2, 0x9a, 0x57, 0xf7, 0x71, 0xc2, 0xdc, 0x45, 0x90, 0x53, 0x11, 0x43, 0xa9, 0xbe,
-	0xef, 0x54, 0x76, 0x16, 0x94, 0xb9, 0x9b, 0x22, 0x9f, 0x82, 0x8e, 0xc0, 0xd8, 0x4f, 0xb3, 0xb2,
-	0xc4, 0x37, 0xb2, 0x61, 0x56, 0xa1, 0xbc, 0xc4, 0x42, 0x65, 0x37, 0xca, 0xc9, 0x31, 0x73, 0x0b,
-	0x46, 0x1e, 0x41, 0xbb, 0x68, 0x9d, 0xb7, 0x65, 0x5a, 0x80, 0xe6, 0x36, 0x70, 0xf8, 0xbb, 0x0a,
-	0xda, 0x94, 0x25, 0x2b, 0x96, 0xe4, 0xc9, 0x92, 0xff, 0xfc, 0x6f, 0xe4, 0x44, 0x22, 0xcc, 0x5d,
-	0xc4, 0xbd, 0x1e, 0xfc, 0x97, 0x00, 0x92, 0xcc, 0x1c, 0x6d, 0xbc, 0xd8, 0x12, 0x37, 0x77, 0xe0,
-	0xf7, 0x6d, 0x97, 0x3a, 0x99, 0x21, 0x8f, 0xa1, 0xfb, 0xdc, 0x7d, 0xc3, 0x26, 0x79, 0x09, 0x57,
-	0xf7, 0xf9, 0xf0, 0x33, 0xd0, 0x51, 0x66, 0x66, 0xb1, 0x3d, 0xd9, 0xfc, 0x4c, 0xa8, 0x8f, 0xb9,
-	0x0d, 0xcc, 0xfd, 0xe1, 0xf2, 0x69, 0xb4, 0x0e, 0xef, 0xf3, 0xe1, 0x85, 0x86, 0xd8, 0xa3, 0x3f,
-	0x03, 0x00, 0x00, 0xff, 0xff, 0x20, 0xc6, 0xf4, 0x64, 0xdc, 0x0d, 0x00, 0x00,
+	// 1518 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0x4f, 0x73, 0xdb, 0x44,
+	0x14, 0x47, 0x96, 0x25, 0x4b, 0x2f, 0x49, 0xe3, 0x2e, 0x9d, 0x56, 0x88, 0xc2, 0x18, 0x71, 0x20,
+	0x74, 0x86, 0x02, 0x29, 0x50, 0x0e, 0xcc, 0x30, 0x6e, 0x9a, 0x69, 0xcd, 0xa4, 0xa9, 0x91, 0x9b,
+	0x1c, 0xb8, 0x94, 0x8d, 0xb4, 0x49, 0x44, 0x6d, 0x49, 0x23, 0xc9, 0x36, 0x19, 0xe0, 0x46, 0x8f,
+	0xcc, 0x30, 0xdc, 0x38, 0x71, 0xe1, 0x43, 0xf0, 0x9d, 0x18, 0xae, 0x9c, 0x99, 0x7d, 0xbb, 0x92,
+	0x57, 0xb1, 0x9d, 0x12, 0x0f, 0x7f, 0x4e, 0xd9, 0xf7, 0x7b, 0x4f, 0xfb, 0xde, 0xbe, 0xb7, 0xef,
+	0xed, 0x2f, 0x86, 0xab, 0x69, 0x96, 0x14, 0xc9, 0xbb, 0xe9, 0x78, 0x98, 0xb3, 0xdb, 0xb8, 0x26,
+	0x06, 0xfe, 0xf1, 0x7e, 0x69, 0x40, 0xbb, 0xcf, 0xe1, 0x87, 0x8c, 0x0e, 0x8b, 0xd3, 0x9d, 0x53,
+	0x16, 0x3c, 0x23, 0x0e, 0xb4, 0xf2, 0x71, 0x10, 0xb0, 0x3c, 0x77, 0xb4, 0x8e, 0xb6, 0x65, 0xf9,
+	0xa5, 0x48, 0xee, 0x02, 0x8c, 0xd8, 0xe8, 0x88, 0x65, 0xc3, 0x28, 0x2f, 0x9c, 0x46, 0x47, 0xdf,
+	0x5a, 0xdb, 0xbe, 0x21, 0x76, 0xbc, 0xfd, 0xa8, 0x52, 0x88, 0x95, 0xaf, 0x98, 0x12, 0x02, 0xcd,
+	0x82, 0x65, 0x23, 0x47, 0xef, 0x68, 0x5b, 0x4d, 0x1f, 0xd7, 0xe4, 0x55, 0xb0, 0x87, 0x51, 0xfc,
+	0xec, 0x69, 0x98, 0x4c, 0x63, 0xa7, 0x89, 0x8e, 0x2c, 0x0e, 0xdc, 0x4f, 0xa6, 0x31, 0x79, 0x1b,
+	0xcc, 0x64, 0x1a, 0xb3, 0x2c, 0x77, 0x0c, 0xf4, 0x72, 0x55, 0x7a, 0x79, 0x90, 0x25, 0xe3, 0xf4,
+	0x31, 0xd7, 0xf8, 0xd2, 0x80, 0xdc, 0x04, 0x7b, 0x1c, 0x9f, 0x62, 0xfc, 0x67, 0x8e, 0x89, 0xfb,
+	0xcc, 0x00, 0x72, 0x0d, 0x8c, 0x3c, 0x48, 0x32, 0xe6, 0xb4, 0x3a, 0xda, 0x96, 0xe1, 0x0b, 0x81,
+	0xc7, 0x93, 0xd2, 0xe2, 0xd4, 0xb1, 0x3a, 0xda, 0x96, 0xed, 0xe3, 0x9a, 0x5b, 0x66, 0xc9, 0x38,
+	0x0e, 0x1d, 0x1b, 0x83, 0x14, 0x82, 0xf7, 0x31, 0xc0, 0xcc, 0x27, 0xb7, 0x39, 0xe1, 0x12, 0x26,
+	0xc6, 0xf6, 0x85, 0xc0, 0x51, 0x8c, 0xc5, 0x69, 0x08, 0x14, 0x05, 0xef, 0x79, 0x03, 0xda, 0xe7,
+	0x93, 0x42, 0x5c, 0xb0, 0x4e, 0x93, 0xbc, 0x88, 0xe9, 0x88, 0xc9, 0x3d, 0x2a, 0x99, 0x6c, 0x83,
+	0x99, 0x17, 0xb4, 0x18, 0xe7, 0xb8, 0xcf, 0x95, 0x6d, 0xb7, 0x96, 0xd9, 0x01, 0xaa, 0x6e, 0x8b,
+	0x3f, 0xbe, 0xb4, 0x24, 0x1e, 0xac, 0x0f, 0x69, 0x5e, 0xf8, 0x2c, 0x60, 0xd1, 0x84, 0x85, 0x98,
+	0x60, 0xdb, 0xaf, 0x61, 0xbc, 0x9e, 0x43, 0x5a, 0xb0, 0x38, 0x38, 0xc3, 0x34, 0xdb, 0x7e, 0x29,
+	0x56, 0x65, 0x31, 0x96, 0x95, 0xc5, 0x3c, 0x57, 0x96, 0x5a, 0xae, 0x5b, 0x4b, 0x73, 0x6d, 0x29,
+	0xb9, 0xf6, 0x7e, 0xd5, 0x60, 0x5d, 0x3d, 0x82, 0x72, 0x4e, 0xed, 0xef, 0x9e, 0xd3, 0xfb, 0x12,
+	0x4c, 0xf9, 0x35, 0x80, 0xd9, 0xdd, 0x79, 0xd2, 0x3b, 0xdc, 0x6d, 0xbf, 0x44, 0xd6, 0xa0, 0xb5,
+	0xb7, 0xdb, 0x3d, 0xec, 0xed, 0x3f, 0x68, 0x6b, 0x5c, 0xe8, 0x77, 0x07, 0x03, 0xae, 0x69, 0x90,
+	0x4d, 0x58, 0x3b, 0xd8, 0xef, 0x1e, 0x76, 0x7b, 0x7b, 0xdd, 0x7b, 0x7b, 0xbb, 0x6d, 0x9d, 0x5c,
+	0x01, 0x18, 0x1c, 0x0c, 0xfa, 0xbd, 0x9d, 0xde, 0xe3, 0x83, 0x41, 0xbb, 0xc9, 0x0d, 0x1e, 0x75,
+	0x7b, 0xfb, 0x4f, 0x76, 0xf7, 0xbb, 0xfb, 0x3b, 0xbb, 0x6d, 0xc3, 0xfb, 0x43, 0x03, 0x1b, 0x5b,
+	0xe1, 0xb3, 0x24, 0x8a, 0x2f, 0xe8, 0x01, 0x07, 0x5a, 0x23, 0x96, 0xe7, 0xf4, 0x84, 0xc9, 0x72,
+	0x97, 0x22, 0xb9, 0x01, 0xad, 0xa3, 0x28, 0x0e, 0x9f, 0x46, 0xa9, 0x2c, 0x83, 0xc9, 0xc5, 0x5e,
+	0xca, 0x53, 0x8a, 0x8a, 0x34, 0xc9, 0x0a, 0x59, 0x02, 0x8b, 0x03, 0xfd, 0x24, 0x2b, 0xc8, 0x15,
+	0x68, 0x44, 0x29, 0x56, 0xc0, 0xf6, 0x1b, 0x51, 0x8a, 0x57, 0x93, 0xdb, 0x99, 0xf2, 0x6a, 0x72,
+	0x1b, 0xf5, 0xd6, 0xb4, 0xce, 0xdd, 0x9a, 0xd7, 0x01, 0x32, 0x96, 0x0e, 0xa3, 0x80, 0x16, 0x2c,
+	0xc4, 0xcc, 0x5b, 0xbe, 0x82, 0x90, 0xeb, 0x60, 0x06, 0x49, 0x7c, 0x1c, 0x9d, 0xe0, 0xbd, 0x5e,
+	0xf7, 0xa5, 0xe4, 0x7d, 0x0b, 0x80, 0xc7, 0xdd, 0x63, 0x74, 0xc2, 0x56, 0x3a, 0xaf, 0x1a, 0x95,
+	0x7e, 0x61, 0x54, 0xcd, 0xf3, 0x51, 0x79, 0x53, 0x58, 0x43, 0xef, 0x3b, 0x19, 0xa3, 0x05, 0xfb,
+	0xef, 0xd2, 0xed, 0xed, 0xc0, 0x06, 0x3a, 0xc6, 0xa6, 0xde, 0x67, 0xd3, 0x55, 0x5c, 0x7b, 0x5f,
+	0x40, 0x7b, 0xb6, 0xc9, 0x7d, 0x36, 0x64, 0x2b, 0x1e, 0x81, 0x40, 0x53, 0xc9, 0x1e, 0xae, 0xbd,
+	0x48, 0x0d, 0xb0, 0x1b, 0x86, 0xff, 0xd4, 0xc6, 0xa4, 0x0d, 0x7a, 0x94, 0xe6, 0x4e, 0xb3, 0xa3,
+	0x6f, 0xd9, 0x3e, 0x5f, 0x7a, 0x43, 0xf5, 0x18, 0x3e, 0x1b, 0x25, 0x13, 0xf6, 0x2f, 0x7a, 0xfb,
+	0x4d, 0x53, 0xdd, 0x75, 0xf3, 0x3c, 0x3a, 0x59, 0xad, 0xcf, 0xaa, 0x21, 0xac, 0xab, 0x43, 0xf8,
+	0x26, 0xd8, 0x51, 0x5c, 0xb0, 0xec, 0x98, 0x06, 0x4c, 0x56, 0x7d, 0x06, 0x60, 0x88, 0x49, 0xc8,
+	0x64, 0x9f, 0xe1, 0x9a, 0x6c, 0x41, 0x33, 0x4f, 0x59, 0x80, 0x9d, 0xb6, 0xb6, 0x7d, 0x4d, 0x4e,
+	0xa1, 0x5e, 0xf9, 0xcd, 0x20, 0x65, 0x81, 0x8f, 0x16, 0xde, 0x37, 0xb0, 0x51, 0x83, 0x71, 0x70,
+	0x9e, 0xa5, 0xe5, 0x08, 0xc7, 0x35, 0x6f, 0xb4, 0x94, 0x66, 0x2c, 0x2e, 0x64, 0xbc, 0x52, 0xe2,
+	0xb6, 0x93, 0x21, 0x8d, 0x31, 0x5a, 0xc3, 0xc7, 0x35, 0xb7, 0xcd, 0x87, 0x74, 0xc2, 0xca, 0x04,
+	0x49, 0x89, 0xdb, 0x8e, 0x94, 0x30, 0xf9, 0xda, 0xfb, 0x51, 0x03, 0x32, 0xcb, 0xdb, 0x41, 0x4c,
+	0xff, 0xf7, 0xcc, 0x71, 0xda, 0x20, 0xda, 0x57, 0xce, 0xe4, 0x55, 0x62, 0xf1, 0x40, 0xcf, 0x92,
+	0xa9, 0xa3, 0xe3, 0xf3, 0xde, 0x96, 0xc9, 0x97, 0x53, 0x3f, 0x99, 0xfa, 0x5c, 0x49, 0x3a, 0xb0,
+	0x36, 0xa2, 0x3c, 0x94, 0x98, 0xc6, 0x32, 0x36, 0xcb, 0x57, 0x21, 0x7e, 0xa2, 0x30, 0x8b, 0x8e,
+	0x0b, 0xa4, 0x09, 0xb6, 0x2f, 0x04, 0x85, 0x3d, 0x98, 0x2f, 0x62, 0x0f, 0x6f, 0x81, 0x19, 0x70,
+	0xd6, 0x93, 0x3b, 0x2d, 0x34, 0xdd, 0x94, 0xa6, 0x48, 0x85, 0x78, 0x20, 0x52, 0xcd, 0x4b, 0x16,
+	0xd2, 0x51, 0x5a, 0xcd, 0x58, 0x29, 0xf1, 0x08, 0x8e, 0x87, 0x34, 0xcd, 0x1d, 0x5b, 0x44, 0x80,
+	0x82, 0xf7, 0x5c, 0x03, 0xab, 0xdc, 0xa2, 0xea, 0x0f, 0x4d, 0xe9, 0x8f, 0xf2, 0x06, 0x35, 0x94,
+	0x1b, 0xe4, 0x40, 0xab, 0x7c, 0x5b, 0x75, 0x91, 0x46, 0x29, 0xaa, 0x69, 0x6c, 0xd6, 0xd3, 0xf8,
+	0x1a, 0x00, 0x7f, 0xec, 0x9f, 0x62, 0x94, 0xb2, 0x48, 0x36, 0x47, 0xd0, 0xbb, 0xf7, 0x43, 0x03,
+	0xec, 0x2a, 0xa9, 0x17, 0xb2, 0x0f, 0xf1, 0x0e, 0x35, 0xaa, 0x77, 0x48, 0x61, 0x0d, 0x7a, 0x9d,
+	0x35, 0xcc, 0xde, 0xef, 0xe6, 0xca, 0x3c, 0xc5, 0x58, 0xc0, 0x53, 0xce, 0x55, 0xdb, 0x5c, 0x58,
+	0xed, 0x05, 0x64, 0xee, 0x16, 0x18, 0x9c, 0xc0, 0xe5, 0x8e, 0xd5, 0xd1, 0x95, 0x46, 0x7e, 0xc8,
+	0x68, 0x56, 0x1c, 0x31, 0x5a, 0xf4, 0x69, 0x71, 0xea, 0x0b, 0x13, 0xef, 0x6b, 0xd8, 0xa8, 0xe1,
+	0xfc, 0x98, 0x34, 0x0c, 0xb3, 0xf2, 0xea, 0xda, 0x7e, 0x29, 0xf2, 0x84, 0x8c, 0x45, 0x42, 0x2c,
+	0xbf, 0x31, 0xbe, 0x28, 0x21, 0x6f, 0xc2, 0x06, 0xd6, 0x20, 0x2b, 0x4f, 0xd7, 0x9c, 0x3f, 0x9d,
+	0x17, 0x48, 0x22, 0xf9, 0x84, 0x1e, 0x0d, 0x57, 0x1b, 0xb3, 0x6f, 0xa8, 0x1d, 0xb3, 0xa9, 0x5e,
+	0xe9, 0xb2, 0x61, 0xbc, 0x9f, 0x34, 0xb0, 0x4a, 0x64, 0xe1, 0xb5, 0x2b, 0xab, 0xac, 0xcb, 0x2a,
+	0x5f, 0x03, 0x83, 0x77, 0x74, 0x8e, 0xbb, 0xda, 0xbe, 0x10, 0xf8, 0xeb, 0x5d, 0x0d, 0x80, 0x72,
+	0x44, 0x29, 0xc8, 0x8c, 0xf0, 0x1a, 0x0a, 0xe1, 0xe5, 0x1d, 0x92, 0x25, 0xe3, 0x82, 0x89, 0xae,
+	0xb3, 0x7d, 0x29, 0x79, 0xdf, 0xc1, 0xa6, 0x78, 0xeb, 0x91, 0x78, 0x0c, 0xce, 0xe2, 0x60, 0xa5,
+	0xe3, 0xcf, 0x88, 0x8c, 0xae, 0x12, 0x99, 0x17, 0x52, 0x8d, 0xef, 0x35, 0x58, 0x47, 0xff, 0xfd,
+	0x2c, 0x19, 0x25, 0x2b, 0xbe, 0xd4, 0xd7, 0xc1, 0x14, 0xff, 0xce, 0x94, 0x5c, 0x43, 0x48, 0x15,
+	0x83, 0x6e, 0x2a, 0x0c, 0xba, 0x9a, 0xb2, 0x86, 0x32, 0x65, 0xbd, 0xaf, 0x64, 0x14, 0xf7, 0xb2,
+	0x28, 0x3e, 0xe9, 0xf5, 0x57, 0x9d, 0xdf, 0x11, 0x4e, 0x69, 0x39, 0xbf, 0x51, 0x58, 0xf0, 0xd4,
+	0xfe, 0xac, 0xc9, 0xf9, 0xfc, 0xf9, 0x38, 0xc9, 0xc6, 0xa3, 0x95, 0x7c, 0xdd, 0x04, 0x3b, 0xa0,
+	0x71, 0x18, 0x85, 0xb4, 0x28, 0xfd, 0xcd, 0x00, 0x9e, 0x0f, 0x1a, 0x14, 0xd1, 0xa4, 0x9c, 0x47,
+	0x52, 0xe2, 0x3d, 0x3c, 0x8e, 0x33, 0x46, 0x83, 0x53, 0x7e, 0xcd, 0x31, 0x03, 0x96, 0xaf, 0x42,
+	0x5e, 0x26, 0x59, 0xc0, 0x23, 0xa5, 0xaf, 0x57, 0xac, 0x08, 0x8b, 0xd1, 0x89, 0x98, 0x95, 0x52,
+	0xaa, 0xde, 0xab, 0xe6, 0xec, 0xbd, 0xda, 0xfe, 0xdd, 0x00, 0x7d, 0x67, 0xaf, 0x47, 0x6e, 0x41,
+	0x13, 0xd9, 0x7d, 0xf9, 0xdc, 0x54, 0x7c, 0xdf, 0x9d, 0x43, 0xc8, 0x3b, 0x60, 0x08, 0x6a, 0x7c,
+	0x55, 0x55, 0x21, 0xe4, 0xce, 0x43, 0xe4, 0x3d, 0x30, 0x25, 0x97, 0x25, 0xaa, 0x52, 0x60, 0xee,
+	0x02, 0x8c, 0x7c, 0x04, 0xd6, 0x3e, 0x9b, 0x3e, 0x10, 0xff, 0x41, 0xaa, 0xfa, 0x92, 0x9a, 0xba,
+	0x0b, 0x51, 0xf2, 0x29, 0xac, 0x09, 0xca, 0x29, 0x3e, 0xbd, 0x31, 0x67, 0x24, 0xb4, 0xee, 0x32,
+	0x05, 0x29, 0xff, 0xa5, 0xed, 0xf5, 0x39, 0xbd, 0x9c, 0x77, 0xd2, 0x0d, 0x43, 0x77, 0x21, 0x4a,
+	0xba, 0xb0, 0x21, 0xbf, 0x94, 0x6c, 0x71, 0xde, 0x87, 0x50, 0xb8, 0xcb, 0x14, 0x3c, 0x7a, 0x95,
+	0xff, 0xcd, 0xdb, 0x09, 0x85, 0xbb, 0x4c, 0x41, 0x76, 0x61, 0xa3, 0x4e, 0x84, 0x5e, 0x99, 0xb3,
+	0x2c, 0x55, 0xee, 0x72, 0x15, 0x79, 0x1f, 0x6c, 0x04, 0xf6, 0xf8, 0xcf, 0x13, 0x35, 0x7e, 0x80,
+	0x03, 0xda, 0x9d, 0x87, 0x78, 0x89, 0x25, 0xdf, 0xa9, 0x95, 0x53, 0x60, 0xee, 0x02, 0x8c, 0xdc,
+	0x81, 0x56, 0x39, 0x74, 0x5e, 0x56, 0xd5, 0x12, 0x74, 0x17, 0x81, 0x3c, 0x43, 0x6a, 0x6f, 0xd4,
+	0x12, 0xa1, 0x28, 0xdc, 0x65, 0x8a, 0xed, 0x3f, 0x75, 0x30, 0x07, 0x2c, 0x9b, 0xb0, 0x8c, 0xef,
+	0xa5, 0xfe, 0xb2, 0x53, 0xfb, 0x44, 0x51, 0xb8, 0xcb, 0x14, 0x97, 0xea, 0x98, 0x4f, 0x00, 0x94,
+	0x11, 0x7f, 0xbd, 0x76, 0xe5, 0x2b, 0xdc, 0x5d, 0x82, 0x5f, 0xb6, 0xdf, 0x56, 0x4a, 0xed, 0x5d,
+	0x9e, 0xda, 0x67, 0xac, 0xcf, 0xef, 0xc0, 0xe4, 0x32, 0x1f, 0x7e, 0x08, 0x36, 0xce, 0xed, 0x83,
+	0xb4, 0xd7, 0xaf, 0x7f, 0x26, 0xc7, 0xb9, 0xbb, 0x08, 0xe4, 0xfe, 0x70, 0xc9, 0x7f, 0x3b, 0xb9,
+	0xd4, 0x87, 0x1f, 0x00, 0x88, 0xd1, 0x7d, 0x98, 0x9c, 0x9f, 0x28, 0x02, 0x77, 0x17, 0x60, 0x47,
+	0x26, 0x42, 0x77, 0xfe, 0x1a, 0x00, 0xc7, 0x3e, 0x74, 0xef, 0xf0, 0x13, 0x00, 0x00,
 }
@@ -0,0 +1,156 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package checks
+
+import (
+	"errors"
+	"time"
+)
+
+/**
+ * Types of check
+ */
+const (
+	TypeTCP  = "tcp"
+	TypeHTTP = "http"
+	TypeICMP = "icmp"
+	TypeDNS  = "dns"
+	TypeExec = "exec"
+)
+
+/**
+ * Defaults for anything not configured. Timeouts and intervals are in milliseconds.
+ */
+const (
+	defaultTimeout  = 2000
+	defaultInterval = 5000
+	defaultRise     = 2
+	defaultFall     = 3
+)
+
+/**
+ * A config declared health check. The target is the address for tcp checks,
+ * the URL for http checks, the host for icmp checks, the name to resolve for
+ * dns checks and the shell command for exec checks.
+ * A check is marked healthy after rise consecutive passes and unhealthy after
+ * fall consecutive failures.
+ */
+type Check struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Target string `json:"target"`
+	// DNS server to query in the form host:port. Defaults to the system resolver.
+	Server string `json:"server,omitempty"`
+	// Expected HTTP status. Defaults to 200.
+	Status int `json:"status,omitempty"`
+	// Text the HTTP body must contain or an address the DNS answer must contain
+	Expect string `json:"expect,omitempty"`
+	// Skip TLS certificate verification for https checks
+	Insecure bool `json:"insecure,omitempty"`
+	Timeout  int  `json:"timeout"`
+	Interval int  `json:"interval"`
+	Rise     int  `json:"rise"`
+	Fall     int  `json:"fall"`
+	// The active hands over when a tracked check fails
+	Track bool `json:"track,omitempty"`
+	// Points taken from the node's health score while the check is failing
+	Weight int `json:"weight,omitempty"`
+}
+
+/**
+ * The current state of a check
+ */
+type Result struct {
+	Name    string
+	Type    string
+	Track   bool
+	Weight  int
+	Healthy bool
+	// Why the last run failed
+	Message   string
+	LastCheck time.Time
+	// Consecutive passes and failures
+	Passes   int
+	Failures int
+}
+
+/**
+ * Make sure the check can be run
+ */
+func (c Check) Validate() error {
+	if c.Name == "" {
+		return errors.New("checks must have a name")
+	}
+	if _, ok := probes[c.Type]; !ok {
+		return errors.New("check " + c.Name + " has an unknown type. Must be one of: tcp, http, icmp, dns, exec")
+	}
+	if c.Target == "" {
+		return errors.New("check " + c.Name + " has no target")
+	}
+	if c.Timeout < 0 || c.Interval < 0 || c.Rise < 0 || c.Fall < 0 || c.Weight < 0 {
+		return errors.New("check " + c.Name + " timeout, interval, rise, fall and weight must be zero or greater")
+	}
+	return nil
+}
+
+/**
+ * Returns the check with defaults for anything not configured
+ */
+func (c Check) withDefaults() Check {
+	if c.Timeout == 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.Interval == 0 {
+		c.Interval = defaultInterval
+	}
+	if c.Rise == 0 {
+		c.Rise = defaultRise
+	}
+	if c.Fall == 0 {
+		c.Fall = defaultFall
+	}
+	if c.Type == TypeHTTP && c.Status == 0 {
+		c.Status = 200
+	}
+	return c
+}
+
+/**
+ * Record the outcome of a run. Returns whether the check changed state.
+ */
+func (r *Result) record(err error, rise, fall int) bool {
+	r.LastCheck = time.Now()
+	if err == nil {
+		r.Message = ""
+		r.Passes++
+		r.Failures = 0
+		if !r.Healthy && r.Passes >= rise {
+			r.Healthy = true
+			return true
+		}
+		return false
+	}
+	r.Message = err.Error()
+	r.Failures++
+	r.Passes = 0
+	if r.Healthy && r.Failures >= fall {
+		r.Healthy = false
+		return true
+	}
+	return false
+}
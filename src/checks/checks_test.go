@@ -0,0 +1,238 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package checks
+
+import (
+	"context"
+	"errors"
+	"golang.org/x/net/dns/dnsmessage"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+/**
+ * Run a single probe with its defaults
+ */
+func runProbe(c Check) error {
+	c = c.withDefaults()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Timeout)*time.Millisecond)
+	defer cancel()
+	return probes[c.Type](ctx, c)
+}
+
+func TestRiseFall(t *testing.T) {
+	r := &Result{Healthy: true}
+	fail := errors.New("failed")
+	if r.record(fail, 2, 2) || !r.Healthy {
+		t.Fatal("check should stay healthy after a single failure")
+	}
+	if !r.record(fail, 2, 2) || r.Healthy {
+		t.Fatal("check should be unhealthy after two failures")
+	}
+	if r.Message != "failed" {
+		t.Errorf("unexpected message %q", r.Message)
+	}
+	if r.record(nil, 2, 2) || r.Healthy {
+		t.Fatal("check should stay unhealthy after a single pass")
+	}
+	if !r.record(nil, 2, 2) || !r.Healthy {
+		t.Fatal("check should be healthy after two passes")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := (Check{Name: "web", Type: TypeTCP, Target: "127.0.0.1:80"}).Validate(); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+	for _, c := range []Check{
+		{Type: TypeTCP, Target: "127.0.0.1:80"},
+		{Name: "web", Type: "smtp", Target: "127.0.0.1:25"},
+		{Name: "web", Type: TypeTCP},
+		{Name: "web", Type: TypeTCP, Target: "127.0.0.1:80", Fall: -1},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", c)
+		}
+	}
+}
+
+func TestProbeTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	if err := runProbe(Check{Type: TypeTCP, Target: addr}); err != nil {
+		t.Errorf("tcp check failed against a listener: %s", err)
+	}
+	l.Close()
+	if err := runProbe(Check{Type: TypeTCP, Target: addr}); err == nil {
+		t.Error("tcp check passed against a closed port")
+	}
+}
+
+func TestProbeHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			http.Error(w, "maintenance", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("status: ok"))
+	}))
+	defer server.Close()
+	if err := runProbe(Check{Type: TypeHTTP, Target: server.URL, Expect: "ok"}); err != nil {
+		t.Errorf("http check failed: %s", err)
+	}
+	if err := runProbe(Check{Type: TypeHTTP, Target: server.URL, Expect: "healthy"}); err == nil {
+		t.Error("http check passed without the expected body")
+	}
+	if err := runProbe(Check{Type: TypeHTTP, Target: server.URL + "/down"}); err == nil {
+		t.Error("http check passed with an unexpected status")
+	}
+	if err := runProbe(Check{Type: TypeHTTP, Target: server.URL + "/down", Status: 503}); err != nil {
+		t.Errorf("http check failed with the expected status: %s", err)
+	}
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer tlsServer.Close()
+	if err := runProbe(Check{Type: TypeHTTP, Target: tlsServer.URL}); err == nil {
+		t.Error("https check passed with an untrusted certificate")
+	}
+	if err := runProbe(Check{Type: TypeHTTP, Target: tlsServer.URL, Insecure: true}); err != nil {
+		t.Errorf("insecure https check failed: %s", err)
+	}
+}
+
+/**
+ * Serve A records for pulseha.test. from a local DNS server
+ */
+func serveDNS(t *testing.T) (string, func()) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var msg dnsmessage.Message
+			if msg.Unpack(buf[:n]) != nil || len(msg.Questions) != 1 {
+				continue
+			}
+			question := msg.Questions[0]
+			msg.Header.Response = true
+			msg.Header.Authoritative = true
+			if question.Name.String() != "pulseha.test." {
+				msg.Header.RCode = dnsmessage.RCodeNameError
+			} else if question.Type == dnsmessage.TypeA {
+				msg.Answers = []dnsmessage.Resource{{
+					Header: dnsmessage.ResourceHeader{Name: question.Name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET, TTL: 60},
+					Body:   &dnsmessage.AResource{A: [4]byte{10, 0, 0, 10}},
+				}}
+			}
+			if reply, err := msg.Pack(); err == nil {
+				conn.WriteTo(reply, addr)
+			}
+		}
+	}()
+	return conn.LocalAddr().String(), func() { conn.Close() }
+}
+
+func TestProbeDNS(t *testing.T) {
+	server, cleanup := serveDNS(t)
+	defer cleanup()
+	if err := runProbe(Check{Type: TypeDNS, Target: "pulseha.test", Server: server, Expect: "10.0.0.10"}); err != nil {
+		t.Errorf("dns check failed: %s", err)
+	}
+	if err := runProbe(Check{Type: TypeDNS, Target: "pulseha.test", Server: server, Expect: "10.0.0.11"}); err == nil {
+		t.Error("dns check passed without the expected address")
+	}
+	if err := runProbe(Check{Type: TypeDNS, Target: "missing.test", Server: server}); err == nil {
+		t.Error("dns check passed for a name that does not exist")
+	}
+}
+
+func TestProbeExec(t *testing.T) {
+	if err := runProbe(Check{Type: TypeExec, Target: "exit 0"}); err != nil {
+		t.Errorf("exec check failed: %s", err)
+	}
+	err := runProbe(Check{Type: TypeExec, Target: "echo service stopped; exit 1"})
+	if err == nil || err.Error() != "service stopped" {
+		t.Errorf("exec check error %v should be the command output", err)
+	}
+	if err := runProbe(Check{Type: TypeExec, Target: "exec sleep 5", Timeout: 100}); err == nil {
+		t.Error("exec check passed after timing out")
+	}
+}
+
+func TestProbeICMP(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("icmp checks require raw socket privileges")
+	}
+	err := runProbe(Check{Type: TypeICMP, Target: "127.0.0.1"})
+	if err != nil {
+		t.Errorf("icmp check failed against localhost: %s", err)
+	}
+}
+
+func TestRunner(t *testing.T) {
+	changes := make(chan Result, 1)
+	r := &Runner{OnChange: func(result Result) { changes <- result }}
+	r.Start([]Check{{Name: "fails", Type: TypeExec, Target: "exit 1", Interval: 10, Fall: 2}})
+	defer r.Stop()
+	select {
+	case result := <-changes:
+		if result.Healthy || result.Name != "fails" {
+			t.Errorf("unexpected change %+v", result)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the check to fail")
+	}
+	if results := r.Results(); len(results) != 1 || results[0].Healthy || results[0].Failures < 2 {
+		t.Errorf("unexpected results %+v", results)
+	}
+	if failing := r.TrackedFailing(); len(failing) != 0 {
+		t.Errorf("untracked checks should not be reported as tracked failures, got %v", failing)
+	}
+}
+
+func TestTrackedFailing(t *testing.T) {
+	changes := make(chan Result, 2)
+	r := &Runner{OnChange: func(result Result) { changes <- result }}
+	r.Start([]Check{
+		{Name: "nginx", Type: TypeExec, Target: "exit 1", Interval: 10, Fall: 1, Track: true},
+		{Name: "disk", Type: TypeExec, Target: "exit 1", Interval: 10, Fall: 1},
+	})
+	defer r.Stop()
+	for i := 0; i < 2; i++ {
+		select {
+		case <-changes:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the checks to fail")
+		}
+	}
+	if failing := r.TrackedFailing(); len(failing) != 1 || failing[0] != "nginx" {
+		t.Errorf("expected only nginx to be a tracked failure, got %v", failing)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package checks
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+ * Runs a check returning nil when it passes. The context expires with the check timeout.
+ */
+type probe func(ctx context.Context, c Check) error
+
+var probes = map[string]probe{
+	TypeTCP:  probeTCP,
+	TypeHTTP: probeHTTP,
+	TypeICMP: probeICMP,
+	TypeDNS:  probeDNS,
+	TypeExec: probeExec,
+}
+
+/**
+ * Passes when a TCP connection can be established
+ */
+func probeTCP(ctx context.Context, c Check) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", c.Target)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+/**
+ * Passes when the URL returns the expected status and its body contains the expected text
+ */
+func probeHTTP(ctx context.Context, c Check) error {
+	req, err := http.NewRequest("GET", c.Target, nil)
+	if err != nil {
+		return err
+	}
+	// Every probe opens a fresh connection so nothing is left idle between runs
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: c.Insecure},
+			DisableKeepAlives: true,
+		},
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != c.Status {
+		return errors.New("unexpected status " + strconv.Itoa(resp.StatusCode))
+	}
+	if c.Expect == "" {
+		return nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(string(body), c.Expect) {
+		return errors.New("response does not contain " + c.Expect)
+	}
+	return nil
+}
+
+/**
+ * Passes when the host replies to an ICMP echo request
+ */
+func probeICMP(ctx context.Context, c Check) error {
+	addr, err := net.ResolveIPAddr("ip", c.Target)
+	if err != nil {
+		return err
+	}
+	network, proto, echoType, replyType := "ip4:icmp", 1, icmp.Type(ipv4.ICMPTypeEcho), icmp.Type(ipv4.ICMPTypeEchoReply)
+	if addr.IP.To4() == nil {
+		network, proto, echoType, replyType = "ip6:ipv6-icmp", 58, ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+	}
+	conn, err := icmp.ListenPacket(network, "")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	id, seq := os.Getpid()&0xffff, int(time.Now().UnixNano()&0xffff)
+	msg := icmp.Message{
+		Type: echoType,
+		Body: &icmp.Echo{ID: id, Seq: seq, Data: []byte("pulseha")},
+	}
+	buf, err := msg.Marshal(nil)
+	if err != nil {
+		return err
+	}
+	if _, err := conn.WriteTo(buf, addr); err != nil {
+		return err
+	}
+	// Raw sockets see every ICMP message so wait for our reply
+	reply := make([]byte, 1500)
+	for {
+		n, peer, err := conn.ReadFrom(reply)
+		if err != nil {
+			return err
+		}
+		if !peer.(*net.IPAddr).IP.Equal(addr.IP) {
+			continue
+		}
+		m, err := icmp.ParseMessage(proto, reply[:n])
+		if err != nil || m.Type != replyType {
+			continue
+		}
+		if echo, ok := m.Body.(*icmp.Echo); ok && echo.ID == id && echo.Seq == seq {
+			return nil
+		}
+	}
+}
+
+/**
+ * Passes when the name resolves and the answer contains the expected address
+ */
+func probeDNS(ctx context.Context, c Check) error {
+	resolver := net.DefaultResolver
+	if c.Server != "" {
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, c.Server)
+			},
+		}
+	}
+	addrs, err := resolver.LookupHost(ctx, c.Target)
+	if err != nil {
+		return err
+	}
+	if c.Expect == "" {
+		return nil
+	}
+	for _, addr := range addrs {
+		if addr == c.Expect {
+			return nil
+		}
+	}
+	return errors.New(c.Target + " does not resolve to " + c.Expect)
+}
+
+/**
+ * Passes when the command exits with a zero status
+ */
+func probeExec(ctx context.Context, c Check) error {
+	output, err := exec.CommandContext(ctx, "/bin/sh", "-c", c.Target).CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return errors.New("timed out")
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package checks
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+/**
+ * Runs each check on its own interval and keeps track of their results
+ */
+type Runner struct {
+	sync.Mutex
+	checks  []Check
+	results map[string]*Result
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	// Called whenever a check changes state
+	OnChange func(Result)
+}
+
+/**
+ * Start running the checks. Any checks already running are stopped first.
+ * Checks start healthy so a freshly started node is not penalised before its checks have run.
+ */
+func (r *Runner) Start(checks []Check) {
+	r.Stop()
+	r.Lock()
+	defer r.Unlock()
+	r.checks = nil
+	r.results = make(map[string]*Result)
+	r.stop = make(chan struct{})
+	for _, check := range checks {
+		check = check.withDefaults()
+		r.checks = append(r.checks, check)
+		r.results[check.Name] = &Result{Name: check.Name, Type: check.Type, Track: check.Track, Weight: check.Weight, Healthy: true}
+		r.wg.Add(1)
+		go r.run(check, r.stop)
+	}
+}
+
+/**
+ * Stop running the checks
+ */
+func (r *Runner) Stop() {
+	r.Lock()
+	if r.stop != nil {
+		close(r.stop)
+		r.stop = nil
+	}
+	r.Unlock()
+	r.wg.Wait()
+}
+
+/**
+ * Returns a copy of the results in the order the checks were configured
+ */
+func (r *Runner) Results() []Result {
+	r.Lock()
+	defer r.Unlock()
+	var results []Result
+	for _, check := range r.checks {
+		results = append(results, *r.results[check.Name])
+	}
+	return results
+}
+
+/**
+ * Returns the names of the tracked checks that are failing
+ */
+func (r *Runner) TrackedFailing() []string {
+	var failing []string
+	for _, result := range r.Results() {
+		if result.Track && !result.Healthy {
+			failing = append(failing, result.Name)
+		}
+	}
+	return failing
+}
+
+/**
+ * Run a check until we are stopped
+ */
+func (r *Runner) run(check Check, stop chan struct{}) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(time.Duration(check.Interval) * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		r.runOnce(check)
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+/**
+ * Run a check once and record its result
+ */
+func (r *Runner) runOnce(check Check) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(check.Timeout)*time.Millisecond)
+	err := probes[check.Type](ctx, check)
+	cancel()
+	r.Lock()
+	result, ok := r.results[check.Name]
+	if !ok {
+		r.Unlock()
+		return
+	}
+	changed := result.record(err, check.Rise, check.Fall)
+	current := *result
+	onChange := r.OnChange
+	r.Unlock()
+	if changed && onChange != nil {
+		onChange(current)
+	}
+}
@@ -24,7 +24,6 @@ import (
 	log "github.com/Sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
-	"time"
 )
 
 type Client struct {
@@ -44,6 +43,7 @@ const (
 	SendBringDownIP
 	SendHealthCheck
 	SendPromote
+	SendQuorumVote
 )
 
 var protoFunctions = []string{
@@ -55,6 +55,7 @@ var protoFunctions = []string{
 	"BringDownIP",
 	"HealthCheck",
 	"Promote",
+	"QuorumVote",
 }
 
 func (p protoFunction) String() string {
@@ -92,6 +93,9 @@ func (c *Client) GetProtoFuncList() map[string]interface{} {
 		"Promote": func(ctx context.Context, data interface{}) (interface{}, error) {
 			return c.Requester.Promote(ctx, data.(*p.PulsePromote))
 		},
+		"QuorumVote": func(ctx context.Context, data interface{}) (interface{}, error) {
+			return c.Requester.QuorumVote(ctx, data.(*p.PulseQuorum))
+		},
 	}
 	return funcList
 }
@@ -138,7 +142,7 @@ Send a specific GRPC call
 func (c *Client) Send(funcName protoFunction, data interface{}) (interface{}, error) {
 	log.Debug("Client:Send() Sending " + funcName.String())
 	funcList := c.GetProtoFuncList()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout())
 	defer cancel()
 	return funcList[funcName.String()].(func(context.Context, interface{}) (interface{}, error))(
 		ctx, data,
@@ -36,6 +36,7 @@ Server struct type
 type CLIServer struct {
 	sync.Mutex
 	Server     *Server
+	GRPC       *grpc.Server
 	Listener   net.Listener
 	Memberlist *Memberlist
 }
@@ -198,7 +199,7 @@ func (s *CLIServer) Create(ctx context.Context, in *proto.PulseCreate) (*proto.P
 				newNode.IPGroups[ifaceName] = make([]string, 0)
 				groupName := GenGroupName()
 				gconf.Groups[groupName] = []string{}
-				GroupAssign(groupName, utils.GetHostname(), ifaceName)
+				GroupAssign(groupName, utils.GetHostname(), ifaceName, nil)
 			}
 		}
 		gconf.Save()
@@ -353,7 +354,17 @@ func (s *CLIServer) GroupAssign(ctx context.Context, in *proto.PulseGroupAssign)
 	log.Debug("CLIServer:GroupAssign() - Assigning group " + in.Group + " to interface " + in.Interface + " on node " + in.Node)
 	s.Lock()
 	defer s.Unlock()
-	err := GroupAssign(in.Group, in.Node, in.Interface)
+	var spec *netUtils.LinkSpec
+	if in.Spec != nil {
+		spec = &netUtils.LinkSpec{
+			Type:   in.Spec.Type,
+			Parent: in.Spec.Parent,
+			VLAN:   int(in.Spec.Vlan),
+			Slaves: in.Spec.Slaves,
+			Mode:   in.Spec.Mode,
+		}
+	}
+	err := GroupAssign(in.Group, in.Node, in.Interface, spec)
 	if err != nil {
 		return &proto.PulseGroupAssign{
 			Success: false,
@@ -401,7 +412,14 @@ func (s *CLIServer) GroupList(ctx context.Context, in *proto.GroupTable) (*proto
 	config := gconf.GetConfig()
 	for name, ips := range config.Groups {
 		nodes, interfaces := getGroupNodes(name)
-		row := &proto.GroupRow{Name: name, Ip: ips, Nodes: nodes, Interfaces: interfaces}
+		row := &proto.GroupRow{
+			Name:       name,
+			Ip:         ips,
+			Nodes:      nodes,
+			Interfaces: interfaces,
+			Owner:      s.Memberlist.getGroupOwner(name),
+			Routes:     config.GetGroupSettings(name).Routes,
+		}
 		table.Row = append(table.Row, row)
 	}
 	return table, nil
@@ -415,6 +433,14 @@ func (s *CLIServer) Status(ctx context.Context, in *proto.PulseStatus) (*proto.P
 	s.Lock()
 	defer s.Unlock()
 	table := new(proto.PulseStatus)
+	table.Maintenance = clusterMaintenance()
+	table.Drift = driftEvents.get()
+	table.Owners = s.Memberlist.groupOwnersProto()
+	table.Checks = checkRows()
+	table.Damped = failOverDamped()
+	table.Flaps = flaps.history()
+	activeHostname, _ := s.Memberlist.getActiveMember()
+	localActive := activeHostname == gconf.getLocalNode()
 	for _, member := range s.Memberlist.Members {
 		details, _ := NodeGetByName(member.Hostname)
 		tym := member.getLastHCResponse()
@@ -424,12 +450,26 @@ func (s *CLIServer) Status(ctx context.Context, in *proto.PulseStatus) (*proto.P
 		} else {
 			tymFormat = tym.Format(time.RFC1123)
 		}
+		status := member.getStatus()
+		// The active keeps its status so we always know who holds the groups
+		if details.Maintenance && status != proto.MemberStatus_ACTIVE {
+			status = proto.MemberStatus_MAINTENANCE
+		}
 		row := &proto.StatusRow{
 			Hostname: member.getHostname(),
 			Ip:       details.IP,
 			Latency:     member.getLatency(),
-			Status:   member.getStatus(),
+			Status:   status,
 			LastReceived: tymFormat,
+			Maintenance: details.Maintenance,
+			Score:       member.getScore(),
+		}
+		// The active knows how each path to its members is doing whereas a passive
+		// can only tell which of its own paths health checks are arriving on
+		if localActive && member.getHostname() != gconf.getLocalNode() {
+			row.Paths = member.pathRows()
+		} else if !localActive && member.getHostname() == gconf.getLocalNode() {
+			row.Paths = heartbeats.rows(details.heartbeatAddresses(), time.Now(), failOverWarning())
 		}
 		table.Row = append(table.Row, row)
 	}
@@ -443,6 +483,24 @@ func (s *CLIServer) Promote(ctx context.Context, in *proto.PulsePromote) (*proto
 	log.Debug("CLIServer:Promote() - Promote a new member")
 	s.Lock()
 	defer s.Unlock()
+	if in.Group != "" {
+		if err := s.Memberlist.PromoteGroup(in.Group, in.Member); err != nil {
+			return &proto.PulsePromote{
+				Success: false,
+				Message: err.Error(),
+			}, nil
+		}
+		return &proto.PulsePromote{
+			Success: true,
+			Message: "Successfully promoted member " + in.Member + " for group " + in.Group,
+		}, nil
+	}
+	if nodeInMaintenance(in.Member) {
+		return &proto.PulsePromote{
+			Success: false,
+			Message: "Unable to promote member " + in.Member + " as it is in maintenance",
+		}, nil
+	}
 	err := s.Memberlist.PromoteMember(in.Member)
 	if err != nil {
 		return &proto.PulsePromote{
@@ -450,22 +508,74 @@ func (s *CLIServer) Promote(ctx context.Context, in *proto.PulsePromote) (*proto
 			Message: err.Error(),
 		}, nil
 	}
+	if in.Member == "" {
+		return &proto.PulsePromote{
+			Success: true,
+			Message: "Successfully promoted the best available member",
+		}, nil
+	}
 	return &proto.PulsePromote{
 		Success: true,
 		Message: "Successfully promoted member " + in.Member,
 	}, nil
 }
 
+/**
+Handle CLI maintenance request
+*/
+func (s *CLIServer) Maintenance(ctx context.Context, in *proto.PulseMaintenance) (*proto.PulseMaintenance, error) {
+	log.Debug("CLIServer:Maintenance() - Set maintenance mode")
+	s.Lock()
+	defer s.Unlock()
+	if !gconf.ClusterCheck() {
+		return &proto.PulseMaintenance{
+			Success: false,
+			Message: "Unable to set maintenance mode as no cluster was found",
+		}, nil
+	}
+	if err := SetMaintenance(in.Node, in.Enable); err != nil {
+		return &proto.PulseMaintenance{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	gconf.Save()
+	s.Memberlist.SyncConfig()
+	state := "disabled"
+	if in.Enable {
+		state = "enabled"
+	}
+	target := "the cluster"
+	if in.Node != "" {
+		target = in.Node
+	}
+	return &proto.PulseMaintenance{
+		Success: true,
+		Message: "Maintenance mode " + state + " for " + target,
+	}, nil
+}
+
 /**
 Setup pulse cli type
 */
 func (s *CLIServer) Setup() {
 	log.Info("CLI server initialised on 127.0.0.1:9443")
-	lis, err := net.Listen("tcp", "127.0.0.1:9443")
+	var err error
+	s.Listener, err = net.Listen("tcp", "127.0.0.1:9443")
 	if err != nil {
 		log.Errorf("Failed to listen: %s", err)
 	}
-	grpcServer := grpc.NewServer()
-	proto.RegisterCLIServer(grpcServer, s)
-	grpcServer.Serve(lis)
+	s.GRPC = grpc.NewServer()
+	proto.RegisterCLIServer(s.GRPC, s)
+	s.GRPC.Serve(s.Listener)
+}
+
+/**
+Shutdown pulse cli server
+*/
+func (s *CLIServer) shutdown() {
+	log.Debug("Shutting down CLI server")
+	if s.GRPC != nil {
+		s.GRPC.GracefulStop()
+	}
 }
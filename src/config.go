@@ -19,33 +19,134 @@ package main
 
 import (
 	"encoding/json"
+	"github.com/Syleron/PulseHA/src/checks"
+	"github.com/Syleron/PulseHA/src/netUtils"
 	"github.com/Syleron/PulseHA/src/utils"
 	log "github.com/Sirupsen/logrus"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 )
 
 type Config struct {
 	Pulse     Local               `json:"pulse"`
+	Timers    Timers              `json:"timers"`
 	Groups    map[string][]string `json:"floating_ip_groups"`
-	Nodes     map[string]Node     `json:"nodes"`
-	Logging   Logging             `json:"logging"`
-	localNode string
+	// Optional per group settings keyed by group name
+	GroupSettings map[string]GroupSettings `json:"group_settings"`
+	Nodes         map[string]Node          `json:"nodes"`
+	Checks        []checks.Check           `json:"checks"`
+	Scoring       Scoring                  `json:"scoring"`
+	Logging       Logging                  `json:"logging"`
+	localNode     string
 }
 
 type Local struct {
-	TLS bool `json:"tls"`
+	TLS          bool   `json:"tls"`
+	HCPolicy     string `json:"hc_policy"`
+	FencePolicy  string `json:"fence_policy"`
+	Preempt      bool   `json:"preempt"`
+	PreemptDelay int    `json:"preempt_delay"`
+	Maintenance  bool   `json:"maintenance"`
+	ActiveActive bool   `json:"active_active"`
+	// Role changes of a single member within the flap window before
+	// automatic failover is suppressed. Zero disables flap damping.
+	FlapThreshold int `json:"flap_threshold"`
 }
 
+/**
+ * Timers are all in milliseconds
+ */
+type Timers struct {
+	HealthCheckInterval int `json:"health_check_interval"`
+	MonitorInterval     int `json:"monitor_interval"`
+	FailOverWarning     int `json:"failover_warning"`
+	FailOverLimit       int `json:"failover_limit"`
+	RPCTimeout          int `json:"rpc_timeout"`
+	ReconcileInterval   int `json:"reconcile_interval"`
+	// How long to wait after handing over because of a failed tracked check
+	// before we can become active or hand over again
+	HoldDown int `json:"hold_down"`
+	// The sliding window role changes are counted over for flap damping
+	FlapWindow int `json:"flap_window"`
+}
+
+/**
+ * Default timer values
+ */
+const (
+	defaultHealthCheckInterval = 1000
+	defaultMonitorInterval     = 2000
+	defaultFailOverWarning     = 4000
+	defaultFailOverLimit       = 10000
+	defaultRPCTimeout          = 5000
+	defaultReconcileInterval   = 5000
+	defaultHoldDown            = 60000
+	defaultFlapWindow          = 300000
+)
+
+/**
+ * How a group's IPs are announced after it has been brought up and the routes
+ * that move with the group. The interval is in milliseconds and routes are in
+ * the form "destination [via gateway]". An announce count of zero disables
+ * announcements and leaving it unset uses the default. A non zero VRID brings the group's IPs up
+ * on a macvlan interface with the matching VRRP virtual MAC. VRIDs must be unique
+ * across groups.
+ */
+type GroupSettings struct {
+	AnnounceCount    *int     `json:"announce_count,omitempty"`
+	AnnounceInterval int      `json:"announce_interval"`
+	Routes           []string `json:"routes"`
+	VRID             int      `json:"vrid"`
+}
+
+/**
+ * Points taken from a node's health score for each interface carrying floating
+ * IPs that is down. Check weights are set on the checks themselves. The active
+ * hands over to a node whose score beats its own by the margin. A margin of zero
+ * disables this.
+ * Note: Health check plugins are not scored as they judge the active rather than
+ * the local node.
+ */
+type Scoring struct {
+	LinkWeight int `json:"link_weight"`
+	Margin     int `json:"margin"`
+}
+
+/**
+ * Returns how many times a group's IPs are announced
+ */
+func (g GroupSettings) announceCount() int {
+	if g.AnnounceCount == nil {
+		return defaultAnnounceCount
+	}
+	return *g.AnnounceCount
+}
+
+/**
+ * Default group settings
+ */
+const (
+	defaultAnnounceCount    = 3
+	defaultAnnounceInterval = 1000
+)
+
 type Nodes struct {
 	Nodes map[string]Node
 }
 
 type Node struct {
-	IP       string              `json:"bind_address"`
-	Port     string              `json:"bind_port"`
-	IPGroups map[string][]string `json:"group_assignments"`
+	IP          string              `json:"bind_address"`
+	Port        string              `json:"bind_port"`
+	Priority    int                 `json:"priority"`
+	Maintenance bool                `json:"maintenance"`
+	IPGroups    map[string][]string `json:"group_assignments"`
+	// Interfaces created by PulseHA keyed by interface name
+	Interfaces map[string]netUtils.LinkSpec `json:"interfaces,omitempty"`
+	// Additional addresses (ip or ip:port) health checks are exchanged over
+	HeartbeatAddresses []string `json:"heartbeat_addresses,omitempty"`
 }
 
 type Logging struct {
@@ -166,6 +267,94 @@ func (c *Config) Validate() {
 		}
 	}
 
+	// Default to the most conservative health check plugin policy
+	switch c.Pulse.HCPolicy {
+	case "":
+		c.Pulse.HCPolicy = HCPolicyAll
+	case HCPolicyAll, HCPolicyAny, HCPolicyMajority:
+	default:
+		log.Error("Invalid hc_policy. Must be one of: all, any, majority")
+		success = false
+	}
+
+	// Never take over floating IPs from a node we could not fence unless told otherwise
+	switch c.Pulse.FencePolicy {
+	case "":
+		c.Pulse.FencePolicy = FencePolicyAbort
+	case FencePolicyAbort, FencePolicyContinue:
+	default:
+		log.Error("Invalid fence_policy. Must be one of: abort, continue")
+		success = false
+	}
+
+	// Set our default timers and make sure they make sense
+	c.Timers.setDefaults()
+	if !c.Timers.valid() {
+		log.Error("Invalid timers. All timers must be greater than zero and the health check interval and failover warning must be less than the failover limit")
+		success = false
+	}
+
+	vrids := make(map[int]string)
+	for name, settings := range c.GroupSettings {
+		if settings.announceCount() < 0 || settings.AnnounceInterval < 0 {
+			log.Error("Invalid group_settings for " + name + ". announce_count and announce_interval must be zero or greater")
+			success = false
+		}
+		if settings.VRID < 0 || settings.VRID > 255 {
+			log.Error("Invalid vrid for group " + name + ". Must be between 1 and 255 or 0 to disable")
+			success = false
+		}
+		// Groups sharing a VRID would share the same virtual MAC interface
+		if other, ok := vrids[settings.VRID]; ok && settings.VRID > 0 {
+			log.Error("Invalid vrid for group " + name + ". VRID " + strconv.Itoa(settings.VRID) + " is already used by group " + other)
+			success = false
+		}
+		vrids[settings.VRID] = name
+		for _, route := range settings.Routes {
+			if _, _, err := netUtils.ParseRoute(route); err != nil {
+				log.Error("Invalid route " + route + " for group " + name + ". Routes must be in the form: destination [via gateway]")
+				success = false
+			}
+		}
+	}
+
+	checkNames := make(map[string]bool)
+	for _, check := range c.Checks {
+		if err := check.Validate(); err != nil {
+			log.Error("Invalid check: " + err.Error())
+			success = false
+		}
+		if checkNames[check.Name] {
+			log.Error("Invalid check: more than one check is named " + check.Name)
+			success = false
+		}
+		checkNames[check.Name] = true
+	}
+
+	if c.Scoring.LinkWeight < 0 || c.Scoring.Margin < 0 {
+		log.Error("Invalid scoring. Weights and the margin must be zero or greater")
+		success = false
+	}
+
+	for name, node := range c.Nodes {
+		for _, address := range node.HeartbeatAddresses {
+			if _, err := heartbeatAddress(address, node.Port); err != nil {
+				log.Error("Invalid heartbeat address " + address + " for node " + name + ". Must be an ip or ip:port")
+				success = false
+			}
+		}
+	}
+
+	if c.Pulse.FlapThreshold < 0 {
+		log.Error("Invalid flap_threshold. Must be zero or greater")
+		success = false
+	}
+
+	if c.Pulse.PreemptDelay < 0 {
+		log.Error("Invalid preempt_delay. Must be zero or greater")
+		success = false
+	}
+
 	// TODO: Check if our hostname exists inthe cluster config
 	// TODO: Check if we have valid network interface names
 
@@ -221,6 +410,104 @@ func (c *Config) GetGroupIface(node string, groupName string) string {
 	return ""
 }
 
+/**
+Returns the settings for a group with defaults for anything not configured
+*/
+func (c *Config) GetGroupSettings(groupName string) GroupSettings {
+	settings := c.GroupSettings[groupName]
+	if settings.AnnounceInterval == 0 {
+		settings.AnnounceInterval = defaultAnnounceInterval
+	}
+	return settings
+}
+
+/**
+ * Set any timers that have not been configured to their defaults
+ */
+func (t *Timers) setDefaults() {
+	if t.HealthCheckInterval == 0 {
+		t.HealthCheckInterval = defaultHealthCheckInterval
+	}
+	if t.MonitorInterval == 0 {
+		t.MonitorInterval = defaultMonitorInterval
+	}
+	if t.FailOverWarning == 0 {
+		t.FailOverWarning = defaultFailOverWarning
+	}
+	if t.FailOverLimit == 0 {
+		t.FailOverLimit = defaultFailOverLimit
+	}
+	if t.RPCTimeout == 0 {
+		t.RPCTimeout = defaultRPCTimeout
+	}
+	if t.ReconcileInterval == 0 {
+		t.ReconcileInterval = defaultReconcileInterval
+	}
+	if t.HoldDown == 0 {
+		t.HoldDown = defaultHoldDown
+	}
+	if t.FlapWindow == 0 {
+		t.FlapWindow = defaultFlapWindow
+	}
+}
+
+/**
+ * Validate the timers against each other
+ */
+func (t *Timers) valid() bool {
+	if t.HealthCheckInterval <= 0 || t.MonitorInterval <= 0 || t.FailOverWarning <= 0 ||
+		t.FailOverLimit <= 0 || t.RPCTimeout <= 0 || t.ReconcileInterval <= 0 || t.HoldDown <= 0 || t.FlapWindow <= 0 {
+		return false
+	}
+	return t.HealthCheckInterval < t.FailOverLimit && t.FailOverWarning < t.FailOverLimit
+}
+
+/**
+ * Return a timer in milliseconds as a duration or its default if it is not set
+ */
+func timerDuration(value, def int) time.Duration {
+	if value <= 0 {
+		value = def
+	}
+	return time.Duration(value) * time.Millisecond
+}
+
+/**
+ * Timer getters. These read the current config each time so that
+ * changes received via a config sync apply without a restart.
+ */
+func healthCheckInterval() time.Duration {
+	return timerDuration(gconf.GetConfig().Timers.HealthCheckInterval, defaultHealthCheckInterval)
+}
+
+func monitorInterval() time.Duration {
+	return timerDuration(gconf.GetConfig().Timers.MonitorInterval, defaultMonitorInterval)
+}
+
+func failOverWarning() time.Duration {
+	return timerDuration(gconf.GetConfig().Timers.FailOverWarning, defaultFailOverWarning)
+}
+
+func failOverLimit() time.Duration {
+	return timerDuration(gconf.GetConfig().Timers.FailOverLimit, defaultFailOverLimit)
+}
+
+func rpcTimeout() time.Duration {
+	return timerDuration(gconf.GetConfig().Timers.RPCTimeout, defaultRPCTimeout)
+}
+
+func reconcileInterval() time.Duration {
+	return timerDuration(gconf.GetConfig().Timers.ReconcileInterval, defaultReconcileInterval)
+}
+
+func holdDown() time.Duration {
+	return timerDuration(gconf.GetConfig().Timers.HoldDown, defaultHoldDown)
+}
+
+func flapWindow() time.Duration {
+	return timerDuration(gconf.GetConfig().Timers.FlapWindow, defaultFlapWindow)
+}
+
 /**
  *
  */
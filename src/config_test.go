@@ -16,3 +16,46 @@
    along with this program.  If not, see <http://www.gnu.org/licenses/>.
 */
 package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimers(t *testing.T) {
+	timers := Timers{}
+	timers.setDefaults()
+	if !timers.valid() {
+		t.Fatal("default timers should be valid")
+	}
+	if timers.FailOverLimit != defaultFailOverLimit {
+		t.Errorf("expected default failover limit %d, got %d", defaultFailOverLimit, timers.FailOverLimit)
+	}
+	timers.FailOverWarning = timers.FailOverLimit
+	if timers.valid() {
+		t.Error("failover warning equal to the failover limit should be invalid")
+	}
+	timers = Timers{HealthCheckInterval: 200, MonitorInterval: 200, FailOverWarning: 400, FailOverLimit: 1000, RPCTimeout: 500, ReconcileInterval: 1000, HoldDown: 1000, FlapWindow: 1000}
+	if !timers.valid() {
+		t.Error("sub-second timers should be valid")
+	}
+	if d := timerDuration(0, defaultRPCTimeout); d != 5*time.Second {
+		t.Errorf("expected the default rpc timeout, got %s", d)
+	}
+}
+
+func TestGroupSettingsAnnounceCount(t *testing.T) {
+	disabled, twice := 0, 2
+	config := Config{
+		GroupSettings: map[string]GroupSettings{
+			"disabled": {AnnounceCount: &disabled},
+			"twice":    {AnnounceCount: &twice},
+		},
+	}
+	tests := map[string]int{"disabled": 0, "twice": 2, "unset": defaultAnnounceCount}
+	for group, want := range tests {
+		if got := config.GetGroupSettings(group).announceCount(); got != want {
+			t.Errorf("expected %s to be announced %d times, got %d", group, want, got)
+		}
+	}
+}
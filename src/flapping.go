@@ -0,0 +1,124 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"github.com/Syleron/PulseHA/proto"
+	log "github.com/Sirupsen/logrus"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+/**
+ * The number of role changes kept for the status history
+ */
+const maxFlapEvents = 10
+
+/**
+ * Tracks when each member gained or lost the active role
+ */
+type flapTracker struct {
+	sync.Mutex
+	changes map[string][]time.Time
+	events  []string
+	damped  bool
+}
+
+var flaps flapTracker
+
+/**
+ * Record a status change. Only changes to or from the active role count
+ * and changes older than the window are forgotten.
+ */
+func (f *flapTracker) record(hostname string, from, to proto.MemberStatus_Status, now time.Time, window time.Duration) {
+	if from == to || (from != proto.MemberStatus_ACTIVE && to != proto.MemberStatus_ACTIVE) {
+		return
+	}
+	f.Lock()
+	defer f.Unlock()
+	if f.changes == nil {
+		f.changes = make(map[string][]time.Time)
+	}
+	f.changes[hostname] = append(prune(f.changes[hostname], now, window), now)
+	f.events = append(f.events, now.Format(time.RFC1123)+" "+hostname+" "+from.String()+" -> "+to.String())
+	if len(f.events) > maxFlapEvents {
+		f.events = f.events[len(f.events)-maxFlapEvents:]
+	}
+}
+
+/**
+ * Returns the members that changed role at least threshold times within the window
+ */
+func (f *flapTracker) flapping(now time.Time, window time.Duration, threshold int) []string {
+	f.Lock()
+	defer f.Unlock()
+	var members []string
+	for hostname, changes := range f.changes {
+		f.changes[hostname] = prune(changes, now, window)
+		if len(f.changes[hostname]) >= threshold {
+			members = append(members, hostname)
+		}
+	}
+	sort.Strings(members)
+	return members
+}
+
+/**
+ * Return a copy of the recorded role changes
+ */
+func (f *flapTracker) history() []string {
+	f.Lock()
+	defer f.Unlock()
+	return append([]string{}, f.events...)
+}
+
+/**
+ * Drop the times that have fallen out of the window
+ */
+func prune(times []time.Time, now time.Time, window time.Duration) []time.Time {
+	i := 0
+	for i < len(times) && now.Sub(times[i]) >= window {
+		i++
+	}
+	return times[i:]
+}
+
+/**
+ * Returns true if a member has been flapping and automatic failover must be suppressed.
+ * Manual promotion is still allowed.
+ */
+func failOverDamped() bool {
+	threshold := gconf.GetConfig().Pulse.FlapThreshold
+	if threshold <= 0 {
+		return false
+	}
+	members := flaps.flapping(time.Now(), flapWindow(), threshold)
+	damped := len(members) > 0
+	flaps.Lock()
+	changed := damped != flaps.damped
+	flaps.damped = damped
+	flaps.Unlock()
+	if changed && damped {
+		log.Warnf("Member(s) %s changed role %d or more times within %s. Suppressing automatic failover", strings.Join(members, ", "), threshold, flapWindow())
+	} else if changed {
+		log.Info("The active role has stopped flapping. Automatic failover has resumed")
+	}
+	return damped
+}
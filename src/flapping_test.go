@@ -0,0 +1,49 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"github.com/Syleron/PulseHA/proto"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFlapTracker(t *testing.T) {
+	var f flapTracker
+	window := time.Minute
+	start := time.Now()
+	// Moving between passive and unavailable is not a role change
+	f.record("node1", proto.MemberStatus_PASSIVE, proto.MemberStatus_UNAVAILABLE, start, window)
+	previous := proto.MemberStatus_PASSIVE
+	for i, status := range []proto.MemberStatus_Status{proto.MemberStatus_ACTIVE, proto.MemberStatus_PASSIVE, proto.MemberStatus_ACTIVE} {
+		f.record("node1", previous, status, start.Add(time.Duration(i)*time.Second), window)
+		previous = status
+	}
+	f.record("node2", proto.MemberStatus_PASSIVE, proto.MemberStatus_ACTIVE, start, window)
+	if members := f.flapping(start.Add(3*time.Second), window, 3); !reflect.DeepEqual(members, []string{"node1"}) {
+		t.Errorf("expected node1 to be flapping, got %v", members)
+	}
+	// The first change falls out of the window
+	if members := f.flapping(start.Add(window), window, 3); len(members) != 0 {
+		t.Errorf("expected no members to be flapping, got %v", members)
+	}
+	if history := f.history(); len(history) != 4 {
+		t.Errorf("expected 4 role changes in the history, got %d", len(history))
+	}
+}
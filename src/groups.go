@@ -5,7 +5,9 @@ import (
 	"github.com/Syleron/PulseHA/src/netUtils"
 	"github.com/Syleron/PulseHA/src/utils"
 	log "github.com/Sirupsen/logrus"
+	"reflect"
 	"strconv"
+	"sync"
 )
 
 /**
@@ -37,6 +39,7 @@ func GroupDelete(groupName string) error {
 	if GroupExist(groupName) {
 		if !NodeAssignedToInterface(groupName) {
 			delete(gconf.Groups, groupName)
+			delete(gconf.GroupSettings, groupName)
 			return nil
 		}
 		return errors.New("group has network interface assignments. Please remove them and try again")
@@ -111,15 +114,43 @@ func GroupIpRemove(groupName string, ips []string) error {
  *
  * @return error
  */
-func GroupAssign(groupName, node, iface string) error {
+func GroupAssign(groupName, node, iface string, spec *netUtils.LinkSpec) error {
 	gconf.Lock()
 	defer gconf.Unlock()
 	if !GroupExist(groupName) {
 		return errors.New("IP group does not exist")
 	}
-	if netUtils.InterfaceExist(iface) {
+	if !NodeExists(node) {
+		return errors.New("node does not exist")
+	}
+	// Interfaces we create only need the interfaces they are built on to exist
+	if spec != nil {
+		if err := spec.Validate(); err != nil {
+			return errors.New("invalid interface specification for " + iface)
+		}
+		for _, lower := range spec.Lowers() {
+			if !netUtils.InterfaceExist(lower) {
+				return errors.New("interface " + lower + " does not exist")
+			}
+		}
+		nodeConf := gconf.Nodes[node]
+		if existing, ok := nodeConf.Interfaces[iface]; ok && !reflect.DeepEqual(existing, *spec) {
+			return errors.New("interface " + iface + " is already defined with a different specification")
+		}
+		if nodeConf.Interfaces == nil {
+			nodeConf.Interfaces = make(map[string]netUtils.LinkSpec)
+		}
+		nodeConf.Interfaces[iface] = *spec
+		gconf.Nodes[node] = nodeConf
+	}
+	if _, managed := gconf.Nodes[node].Interfaces[iface]; managed || netUtils.InterfaceExist(iface) {
 		if exists, _ := NodeInterfaceGroupExists(node, iface, groupName); !exists {
-			gconf.Nodes[node].IPGroups[iface] = append(gconf.Nodes[node].IPGroups[iface], groupName)
+			nodeConf := gconf.Nodes[node]
+			if nodeConf.IPGroups == nil {
+				nodeConf.IPGroups = make(map[string][]string)
+			}
+			nodeConf.IPGroups[iface] = append(nodeConf.IPGroups[iface], groupName)
+			gconf.Nodes[node] = nodeConf
 		} else {
 			log.Warning(groupName + " already exists in node " + node + ".. skipping.")
 		}
@@ -142,6 +173,10 @@ func GroupUnassign(groupName, node, iface string) error {
 	if !netUtils.InterfaceExist(iface) {
 		if exists, i := NodeInterfaceGroupExists(node, iface, groupName); exists {
 			gconf.Nodes[node].IPGroups[iface] = append(gconf.Nodes[node].IPGroups[iface][:i], gconf.Nodes[node].IPGroups[iface][i+1:]...)
+			// Forget interfaces we created once nothing is assigned to them
+			if len(gconf.Nodes[node].IPGroups[iface]) == 0 {
+				delete(gconf.Nodes[node].Interfaces, iface)
+			}
 		} else {
 			log.Warning(groupName + " does not exist in node " + node + ".. skipping.")
 		}
@@ -222,14 +257,98 @@ func makeGroupActive(iface string, groupName string) {
 	//log.Infof("Bringing up IPs on Interface: %s, Group: %s", iface, groupName)
 	// gconf.Reload()
 	configCopy := gconf.GetConfig()
-	bringUpIPs(iface, configCopy.Groups[groupName])
-	//garp?
+	settings := configCopy.GetGroupSettings(groupName)
+	assigned := iface
+	if err := ensureLink(iface); err != nil {
+		log.Errorf("Unable to create interface %s for group %s: %s", iface, groupName, err.Error())
+		return
+	}
+	// Move the group's MAC address with its IPs
+	if settings.VRID > 0 {
+		vmac, err := netUtils.AddVMAC(iface, settings.VRID)
+		if err != nil {
+			log.Errorf("Unable to create the virtual MAC interface for group %s: %s", groupName, err.Error())
+			return
+		}
+		iface = vmac
+	}
+	// Never announce or route addresses we failed to bring up
+	if err := bringUpIPs(iface, configCopy.Groups[groupName]); err != nil {
+		log.Errorf("Unable to bring up group %s on %s: %s", groupName, iface, err.Error())
+		return
+	}
+	heldGroups.set(assigned, groupName, true)
+	// Routes may use the floating IPs so they go in after them
+	if err := addRoutes(iface, settings.Routes); err != nil {
+		log.Errorf("Unable to add routes for group %s: %s", groupName, err.Error())
+	}
+	go announceIPs(iface, configCopy.Groups[groupName], settings)
 }
 
 func makeGroupPassive(iface string, groupName string) {
 	//log.Infof("Bringing down IPs on Interface: %s, Group: %s", iface, groupName)
 	// gconf.Reload()
 	configCopy := gconf.GetConfig()
-	bringDownIPs(iface, configCopy.Groups[groupName])
+	settings := configCopy.GetGroupSettings(groupName)
+	ipIface := groupIface(iface, settings)
+	heldGroups.set(iface, groupName, false)
+	// Nothing to bring down if we never created the interface
+	if netUtils.InterfaceExist(ipIface) || (settings.VRID == 0 && !linkManaged(iface)) {
+		if err := deleteRoutes(ipIface, settings.Routes); err != nil {
+			log.Errorf("Unable to remove routes for group %s: %s", groupName, err.Error())
+		}
+		bringDownIPs(ipIface, configCopy.Groups[groupName])
+		if settings.VRID > 0 {
+			if err := netUtils.DeleteLink(ipIface); err != nil {
+				log.Errorf("Unable to remove the virtual MAC interface for group %s: %s", groupName, err.Error())
+			}
+		}
+	}
+	if err := removeUnusedLink(iface); err != nil {
+		log.Errorf("Unable to remove interface %s for group %s: %s", iface, groupName, err.Error())
+	}
 	//garp?
 }
+
+/**
+ * The groups we have brought up on each interface. Used when the networking
+ * plugin cannot report the IPs it holds.
+ */
+type groupState struct {
+	sync.Mutex
+	up map[string]bool
+}
+
+var heldGroups groupState
+
+/**
+ * Record whether a group is up on an interface
+ */
+func (g *groupState) set(iface, group string, up bool) {
+	g.Lock()
+	defer g.Unlock()
+	if g.up == nil {
+		g.up = make(map[string]bool)
+	}
+	g.up[iface+"/"+group] = up
+}
+
+/**
+ * Whether a group has been brought up on an interface
+ */
+func (g *groupState) held(iface, group string) bool {
+	g.Lock()
+	defer g.Unlock()
+	return g.up[iface+"/"+group]
+}
+
+/**
+ * Returns the interface a group's IPs live on. Groups using a virtual MAC
+ * live on their own macvlan interface on top of the assigned interface.
+ */
+func groupIface(iface string, settings GroupSettings) string {
+	if settings.VRID > 0 {
+		return netUtils.VMACName(settings.VRID)
+	}
+	return iface
+}
@@ -1 +1,66 @@
 package main
+
+import (
+	"errors"
+	"github.com/Syleron/PulseHA/src/netUtils"
+	"github.com/Syleron/PulseHA/src/utils"
+	"testing"
+)
+
+func TestGroupAssignUnknownNode(t *testing.T) {
+	gconf.SetConfig(Config{
+		Groups: map[string][]string{"group1": {"10.0.0.5/24"}},
+		Nodes:  map[string]Node{"node1": {}},
+	})
+	defer gconf.SetConfig(Config{})
+	spec := &netUtils.LinkSpec{Type: netUtils.LinkVLAN, Parent: "lo", VLAN: 10}
+	if err := GroupAssign("group1", "node2", "lo.10", spec); err == nil {
+		t.Error("GroupAssign() should fail for a node that does not exist")
+	}
+	if NodeExists("node2") {
+		t.Error("GroupAssign() should not add unknown nodes to the config")
+	}
+	// Nodes without any group assignments yet
+	if err := GroupAssign("group1", "node1", "lo", nil); err != nil {
+		t.Fatal(err)
+	}
+	if groups := gconf.GetConfig().Nodes["node1"].IPGroups["lo"]; len(groups) != 1 || groups[0] != "group1" {
+		t.Errorf("expected group1 to be assigned to lo, got %v", groups)
+	}
+}
+
+/**
+ * A networking plugin that fails to bring up IPs
+ */
+type failingNet struct {
+	routes int
+}
+
+func (n *failingNet) Name() string                                   { return "failing" }
+func (n *failingNet) Version() float64                               { return 1.0 }
+func (n *failingNet) BringUpIPs(iface string, ips []string) error     { return errors.New("no room") }
+func (n *failingNet) BringDownIPs(iface string, ips []string) error   { return nil }
+func (n *failingNet) AddRoutes(iface string, routes []string) error    { n.routes++; return nil }
+func (n *failingNet) DeleteRoutes(iface string, routes []string) error { return nil }
+
+func TestMakeGroupActiveFailure(t *testing.T) {
+	hostname := utils.GetHostname()
+	gconf.SetConfig(Config{
+		localNode:     hostname,
+		Groups:        map[string][]string{"group1": {"10.0.0.5/24"}},
+		GroupSettings: map[string]GroupSettings{"group1": {Routes: []string{"192.168.50.0/24 via 10.0.0.1"}}},
+		Nodes:         map[string]Node{hostname: {IPGroups: map[string][]string{"eth9": {"group1"}}}},
+	})
+	defer gconf.SetConfig(Config{})
+	plugin := &failingNet{}
+	pulse = &Pulse{Plugins: &Plugins{modules: []*Plugin{{Name: plugin.Name(), Type: PluginNetworking, Plugin: plugin}}}}
+	defer func() { pulse = nil }()
+	heldGroups = groupState{}
+	makeGroupActive("eth9", "group1")
+	if plugin.routes != 0 {
+		t.Error("routes should not be added for a group that failed to come up")
+	}
+	if heldGroups.held("eth9", "group1") {
+		t.Error("a group that failed to come up should not be recorded as held")
+	}
+}
@@ -0,0 +1,103 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"github.com/Syleron/PulseHA/proto"
+	"github.com/Syleron/PulseHA/src/checks"
+	log "github.com/Sirupsen/logrus"
+	"strings"
+	"time"
+)
+
+/**
+ * The config declared health checks for the local node
+ */
+var localChecks = &checks.Runner{}
+
+/**
+ * (Re)start our health checks from the current config
+ */
+func startChecks() {
+	config := gconf.GetConfig()
+	localChecks.OnChange = checkChanged
+	localChecks.Start(config.Checks)
+}
+
+/**
+ * Let the logs know when a check changes state
+ */
+func checkChanged(result checks.Result) {
+	if result.Healthy {
+		log.Info("Health check " + result.Name + " is now passing")
+	} else {
+		log.Warn("Health check " + result.Name + " is now failing: " + result.Message)
+	}
+}
+
+/**
+ * Returns our check results for the CLI status
+ */
+func checkRows() []*proto.CheckRow {
+	var rows []*proto.CheckRow
+	for _, result := range localChecks.Results() {
+		var lastCheck string
+		if !result.LastCheck.IsZero() {
+			lastCheck = result.LastCheck.Format(time.RFC1123)
+		}
+		rows = append(rows, &proto.CheckRow{
+			Name:      result.Name,
+			Type:      result.Type,
+			Healthy:   result.Healthy,
+			Message:   result.Message,
+			LastCheck: lastCheck,
+		})
+	}
+	return rows
+}
+
+/**
+ * Monitor our tracked checks. If we are the active and a tracked check fails
+ * the active role is handed over to the best passive member. We then hold
+ * down for a while so we are not handed the active role straight back and
+ * don't hand over again if our checks flap.
+ */
+func (m *Memberlist) monitorChecks() bool {
+	localMember, err := m.getLocalMember()
+	if err != nil {
+		log.Debug("Memberlist:monitorChecks() Check monitoring has stopped as it seems we are no longer in a cluster")
+		return true
+	}
+	failing := localChecks.TrackedFailing()
+	m.Lock()
+	holding := !m.lastCheckHandover.IsZero() && time.Since(m.lastCheckHandover) < holdDown()
+	m.Unlock()
+	localMember.setUnhealthy(len(failing) > 0 || holding)
+	if len(failing) == 0 || holding {
+		return false
+	}
+	if localMember.getStatus() != proto.MemberStatus_ACTIVE || clusterMaintenance() || failOverDamped() {
+		return false
+	}
+	m.Lock()
+	m.lastCheckHandover = time.Now()
+	m.Unlock()
+	log.Warnf("Tracked health check(s) %s failing. Attempting to hand over the active role", strings.Join(failing, ", "))
+	m.handover()
+	return false
+}
@@ -0,0 +1,291 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Syleron/PulseHA/proto"
+	log "github.com/Sirupsen/logrus"
+	"google.golang.org/grpc/connectivity"
+	"net"
+	"sync"
+	"time"
+)
+
+/**
+ * Returns the address in ip:port form, defaulting to the node's bind port
+ */
+func heartbeatAddress(address, port string) (string, error) {
+	host, hostPort, err := net.SplitHostPort(address)
+	if err != nil {
+		host, hostPort = address, port
+	}
+	if net.ParseIP(host) == nil {
+		return "", errors.New("invalid heartbeat address: " + address)
+	}
+	return net.JoinHostPort(host, hostPort), nil
+}
+
+/**
+ * Returns every address health checks can be exchanged with the node over.
+ * The bind address is always the first path.
+ */
+func (n Node) heartbeatAddresses() []string {
+	addresses := []string{net.JoinHostPort(n.IP, n.Port)}
+	seen := map[string]bool{addresses[0]: true}
+	for _, address := range n.HeartbeatAddresses {
+		address, err := heartbeatAddress(address, n.Port)
+		if err != nil || seen[address] {
+			continue
+		}
+		seen[address] = true
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+/**
+ * A single network path health checks are sent to a member over
+ */
+type heartbeatPath struct {
+	sync.Mutex
+	Address string
+	// The client health checks are sent with. The bind address path shares the member's client.
+	client    *Client
+	own       Client
+	up        bool
+	latency   string
+	lastError string
+}
+
+/**
+ * Returns a path to the address using its own client unless one is shared
+ */
+func newHeartbeatPath(address string, shared *Client) *heartbeatPath {
+	h := &heartbeatPath{Address: address, client: shared}
+	if h.client == nil {
+		h.client = &h.own
+	}
+	return h
+}
+
+/**
+ * Make sure the path has a connection to its address
+ */
+func (h *heartbeatPath) connect(hostname string) error {
+	h.Lock()
+	defer h.Unlock()
+	// The member's own connection is managed by the client connections monitor
+	if h.client != &h.own {
+		if h.client.Connection == nil {
+			return errors.New("member connection has not been initiated")
+		}
+		return nil
+	}
+	if h.client.Connection != nil && h.client.Connection.GetState() != connectivity.Shutdown {
+		return nil
+	}
+	host, port, err := net.SplitHostPort(h.Address)
+	if err != nil {
+		return err
+	}
+	return h.client.Connect(host, port, hostname)
+}
+
+/**
+ * Close the path's connection
+ */
+func (h *heartbeatPath) close() {
+	h.Lock()
+	defer h.Unlock()
+	h.client.Close()
+}
+
+/**
+ * Send a health check over the path recording how long it took
+ */
+func (h *heartbeatPath) send(hostname string, data *proto.PulseHealthCheck) (*proto.PulseHealthCheck, time.Duration, error) {
+	if err := h.connect(hostname); err != nil {
+		h.record(0, err)
+		return nil, 0, err
+	}
+	// Let the receiver know which of its paths this arrived on
+	request := *data
+	request.Path = h.Address
+	startTime := time.Now()
+	r, err := h.client.Send(SendHealthCheck, &request)
+	elapsed := time.Since(startTime)
+	h.record(elapsed, err)
+	if err != nil {
+		log.Debugf("heartbeatPath:send() Health check to %s via %s failed: %s", hostname, h.Address, err.Error())
+		h.close()
+		return nil, elapsed, err
+	}
+	return r.(*proto.PulseHealthCheck), elapsed, nil
+}
+
+/**
+ * Record the outcome of the last health check sent over the path
+ */
+func (h *heartbeatPath) record(elapsed time.Duration, err error) {
+	h.Lock()
+	defer h.Unlock()
+	h.up = err == nil
+	h.latency = ""
+	h.lastError = ""
+	if err != nil {
+		h.lastError = err.Error()
+		return
+	}
+	h.latency = fmt.Sprint(elapsed.Round(time.Millisecond))
+}
+
+/**
+ * Whether the path currently has a usable connection
+ */
+func (h *heartbeatPath) ready() bool {
+	h.Lock()
+	defer h.Unlock()
+	return h.client.Connection != nil && h.client.Connection.GetState() == connectivity.Ready
+}
+
+/**
+ * Returns the state of the path for the CLI
+ */
+func (h *heartbeatPath) row() *proto.HeartbeatPath {
+	h.Lock()
+	defer h.Unlock()
+	return &proto.HeartbeatPath{
+		Address: h.Address,
+		Up:      h.up,
+		Latency: h.latency,
+	}
+}
+
+/**
+ * Bring the member's heartbeat paths in line with its config, connecting any new ones
+ */
+func (m *Member) syncPaths() []*heartbeatPath {
+	node, err := NodeGetByName(m.getHostname())
+	if err != nil {
+		return nil
+	}
+	m.Lock()
+	existing := make(map[string]*heartbeatPath)
+	for _, path := range m.paths {
+		existing[path.Address] = path
+	}
+	m.paths = nil
+	for i, address := range node.heartbeatAddresses() {
+		// The bind address path reuses the connection we already have to the member
+		var shared *Client
+		if i == 0 {
+			shared = &m.Client
+		}
+		path, ok := existing[address]
+		if !ok || (path.client == &m.Client) != (shared != nil) {
+			path = newHeartbeatPath(address, shared)
+		} else {
+			delete(existing, address)
+		}
+		m.paths = append(m.paths, path)
+	}
+	paths := m.paths
+	m.Unlock()
+	// Paths that are no longer configured
+	for _, path := range existing {
+		path.close()
+	}
+	for _, path := range paths {
+		if err := path.connect(m.getHostname()); err != nil {
+			log.Debugf("Member:syncPaths() Unable to connect to %s via %s: %s", m.getHostname(), path.Address, err.Error())
+		}
+	}
+	return paths
+}
+
+/**
+ * Whether any of the member's heartbeat paths has a usable connection
+ */
+func (m *Member) heartbeatReachable() bool {
+	for _, path := range m.syncPaths() {
+		if path.ready() {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Returns the state of each heartbeat path to the member for the CLI
+ */
+func (m *Member) pathRows() []*proto.HeartbeatPath {
+	m.Lock()
+	paths := m.paths
+	m.Unlock()
+	rows := []*proto.HeartbeatPath{}
+	for _, path := range paths {
+		rows = append(rows, path.row())
+	}
+	return rows
+}
+
+/**
+ * Tracks when a health check last arrived on each of our heartbeat paths
+ */
+type heartbeatLog struct {
+	sync.Mutex
+	received map[string]time.Time
+}
+
+var heartbeats heartbeatLog
+
+/**
+ * Record a health check arriving on a path
+ */
+func (h *heartbeatLog) record(address string, at time.Time) {
+	if address == "" {
+		return
+	}
+	h.Lock()
+	defer h.Unlock()
+	if h.received == nil {
+		h.received = make(map[string]time.Time)
+	}
+	h.received[address] = at
+}
+
+/**
+ * Returns the state of each of the addresses for the CLI.
+ * A path is down once it has gone longer than the limit without a health check.
+ */
+func (h *heartbeatLog) rows(addresses []string, now time.Time, limit time.Duration) []*proto.HeartbeatPath {
+	h.Lock()
+	defer h.Unlock()
+	rows := []*proto.HeartbeatPath{}
+	for _, address := range addresses {
+		row := &proto.HeartbeatPath{Address: address}
+		if received, ok := h.received[address]; ok {
+			row.Up = now.Sub(received) < limit
+			row.LastReceived = received.Format(time.RFC1123)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
@@ -0,0 +1,103 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHeartbeatAddress(t *testing.T) {
+	tests := []struct {
+		address  string
+		expected string
+		valid    bool
+	}{
+		{"10.0.0.1", "10.0.0.1:8443", true},
+		{"10.0.0.1:9000", "10.0.0.1:9000", true},
+		{"fd00::1", "[fd00::1]:8443", true},
+		{"[fd00::1]:9000", "[fd00::1]:9000", true},
+		{"node1", "", false},
+		{"", "", false},
+	}
+	for _, test := range tests {
+		address, err := heartbeatAddress(test.address, "8443")
+		if (err == nil) != test.valid || address != test.expected {
+			t.Errorf("heartbeatAddress(%q) = %q, %v", test.address, address, err)
+		}
+	}
+}
+
+func TestNodeHeartbeatAddresses(t *testing.T) {
+	node := Node{
+		IP:                 "192.168.1.1",
+		Port:               "8443",
+		HeartbeatAddresses: []string{"10.0.0.1", "192.168.1.1:8443", "bogus", "10.0.0.1:8443"},
+	}
+	expected := []string{"192.168.1.1:8443", "10.0.0.1:8443"}
+	if addresses := node.heartbeatAddresses(); !reflect.DeepEqual(addresses, expected) {
+		t.Errorf("expected %v, got %v", expected, addresses)
+	}
+}
+
+func TestHeartbeatLogRows(t *testing.T) {
+	var h heartbeatLog
+	now := time.Now()
+	h.record("", now)
+	h.record("192.168.1.1:8443", now.Add(-time.Minute))
+	h.record("10.0.0.1:8443", now.Add(-time.Second))
+	rows := h.rows([]string{"192.168.1.1:8443", "10.0.0.1:8443", "10.0.1.1:8443"}, now, 10*time.Second)
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	if rows[0].Up || !rows[1].Up || rows[2].Up {
+		t.Errorf("unexpected path states: %v %v %v", rows[0].Up, rows[1].Up, rows[2].Up)
+	}
+	if rows[2].LastReceived != "" {
+		t.Errorf("expected no last received time for an unused path")
+	}
+}
+
+func TestMemberSyncPaths(t *testing.T) {
+	gconf.SetConfig(Config{
+		Nodes: map[string]Node{
+			"node1": {IP: "127.0.0.1", Port: "8443", HeartbeatAddresses: []string{"127.0.0.2"}},
+		},
+	})
+	defer gconf.SetConfig(Config{})
+	member := &Member{Hostname: "node1"}
+	defer member.Close()
+	paths := member.syncPaths()
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 paths, got %d", len(paths))
+	}
+	// The bind address path shares the member's connection
+	if paths[0].client != &member.Client || paths[1].client == &member.Client {
+		t.Error("only the bind address path should share the member's client")
+	}
+	if again := member.syncPaths(); again[1] != paths[1] {
+		t.Error("syncPaths() should keep existing paths")
+	}
+	gconf.SetConfig(Config{
+		Nodes: map[string]Node{"node1": {IP: "127.0.0.1", Port: "8443"}},
+	})
+	if paths := member.syncPaths(); len(paths) != 1 {
+		t.Errorf("expected the removed path to be dropped, got %d paths", len(paths))
+	}
+}
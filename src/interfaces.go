@@ -0,0 +1,88 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"github.com/Syleron/PulseHA/src/netUtils"
+)
+
+/**
+ * Returns whether the interface is created by PulseHA on the local node
+ */
+func linkManaged(iface string) bool {
+	config := gconf.GetConfig()
+	_, ok := config.LocalNode().Interfaces[iface]
+	return ok
+}
+
+/**
+ * Create the interface if it is one we manage and it does not exist yet
+ */
+func ensureLink(iface string) error {
+	config := gconf.GetConfig()
+	spec, ok := config.LocalNode().Interfaces[iface]
+	if !ok {
+		return nil
+	}
+	return netUtils.AddLink(iface, spec)
+}
+
+/**
+ * Remove an interface we manage once none of its groups are up on it
+ */
+func removeUnusedLink(iface string) error {
+	config := gconf.GetConfig()
+	node := config.LocalNode()
+	if _, ok := node.Interfaces[iface]; !ok || !netUtils.InterfaceExist(iface) {
+		return nil
+	}
+	var floating []string
+	for _, group := range node.IPGroups[iface] {
+		// Virtual MAC interfaces sit on top of ours
+		if settings := config.GetGroupSettings(group); settings.VRID > 0 && netUtils.InterfaceExist(groupIface(iface, settings)) {
+			return nil
+		}
+		floating = append(floating, config.Groups[group]...)
+	}
+	actual, err := netUtils.ListAddresses(iface)
+	if err != nil {
+		return err
+	}
+	if _, stray := addressDrift(nil, floating, actual); len(stray) > 0 {
+		return nil
+	}
+	return netUtils.DeleteLink(iface)
+}
+
+/**
+ * Returns whether the link carrying an interface is up. Interfaces we manage
+ * may not exist while we are passive so the interfaces they are built on are
+ * checked instead. A bond is up while any of its slaves are up.
+ */
+func carrierUp(node Node, iface string, linkUp func(iface string) bool) bool {
+	spec, ok := node.Interfaces[iface]
+	if !ok {
+		return linkUp(iface)
+	}
+	for _, lower := range spec.Lowers() {
+		if linkUp(lower) {
+			return true
+		}
+	}
+	return false
+}
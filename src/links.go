@@ -0,0 +1,94 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"github.com/Syleron/PulseHA/proto"
+	"github.com/Syleron/PulseHA/src/netUtils"
+	log "github.com/Sirupsen/logrus"
+	"strings"
+	"time"
+)
+
+/**
+ * Returns the interfaces of a node that carry floating IPs but are down.
+ * Interfaces with no floating IPs assigned are ignored.
+ */
+func linksDown(node Node, groups map[string][]string, linkUp func(iface string) bool) []string {
+	var down []string
+	for iface, ifaceGroups := range node.IPGroups {
+		hasIPs := false
+		for _, group := range ifaceGroups {
+			if len(groups[group]) > 0 {
+				hasIPs = true
+				break
+			}
+		}
+		if hasIPs && !carrierUp(node, iface, linkUp) {
+			down = append(down, iface)
+		}
+	}
+	return down
+}
+
+/**
+ * Returns the interfaces of the local node that carry floating IPs but are down.
+ */
+func localLinksDown() []string {
+	config := gconf.GetConfig()
+	return linksDown(config.LocalNode(), config.Groups, netUtils.NetInterfaceStatus)
+}
+
+/**
+ * Monitor the link state of the interfaces carrying our floating IPs.
+ * If we are the active and a link goes down the active role is handed
+ * over to a member whose links are all up.
+ */
+func (m *Memberlist) monitorLinks() bool {
+	localMember, err := m.getLocalMember()
+	if err != nil {
+		log.Debug("Memberlist:monitorLinks() Link monitoring has stopped as it seems we are no longer in a cluster")
+		return true
+	}
+	down := localLinksDown()
+	wasDown := localMember.getLinkDown()
+	localMember.setLinkDown(len(down) > 0)
+	if len(down) == 0 {
+		if wasDown {
+			log.Info("All floating IP interfaces are up")
+		}
+		return false
+	}
+	if !wasDown {
+		log.Warnf("Link down on interface(s) %s", strings.Join(down, ", "))
+	}
+	if localMember.getStatus() != proto.MemberStatus_ACTIVE || clusterMaintenance() || failOverDamped() {
+		return false
+	}
+	// Don't keep trying to hand over every time we check the links
+	m.Lock()
+	if time.Since(m.lastLinkHandover) < failOverLimit() {
+		m.Unlock()
+		return false
+	}
+	m.lastLinkHandover = time.Now()
+	m.Unlock()
+	log.Warn("Attempting to hand over the active role as our floating IP interfaces are down")
+	m.handover()
+	return false
+}
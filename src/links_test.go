@@ -0,0 +1,76 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"github.com/Syleron/PulseHA/src/netUtils"
+	"reflect"
+	"testing"
+)
+
+func TestLinksDown(t *testing.T) {
+	node := Node{
+		IPGroups: map[string][]string{
+			"eth0": {"group1"},
+			"eth1": {"group2"},
+			"eth2": {"group3"},
+		},
+	}
+	groups := map[string][]string{
+		"group1": {"10.0.0.10/24"},
+		"group2": {},
+		"group3": {"10.0.1.10/24"},
+	}
+	linkUp := func(iface string) bool {
+		return iface == "eth0"
+	}
+	// eth1 is down but has no floating IPs to carry
+	if down := linksDown(node, groups, linkUp); !reflect.DeepEqual(down, []string{"eth2"}) {
+		t.Errorf("expected only eth2 to be down, got %v", down)
+	}
+}
+
+func TestLinksDownManagedInterfaces(t *testing.T) {
+	node := Node{
+		IPGroups: map[string][]string{
+			"eth0.100": {"group1"},
+			"bond0":    {"group2"},
+		},
+		Interfaces: map[string]netUtils.LinkSpec{
+			"eth0.100": {Type: netUtils.LinkVLAN, Parent: "eth0", VLAN: 100},
+			"bond0":    {Type: netUtils.LinkBond, Slaves: []string{"eth1", "eth2"}},
+		},
+	}
+	groups := map[string][]string{
+		"group1": {"10.0.0.10/24"},
+		"group2": {"10.0.1.10/24"},
+	}
+	// Managed interfaces do not exist while passive so their parent and slaves are checked
+	linkUp := func(iface string) bool {
+		return iface == "eth0" || iface == "eth2"
+	}
+	if down := linksDown(node, groups, linkUp); len(down) != 0 {
+		t.Errorf("expected no links to be down, got %v", down)
+	}
+	linkUp = func(iface string) bool {
+		return iface == "eth1"
+	}
+	if down := linksDown(node, groups, linkUp); !reflect.DeepEqual(down, []string{"eth0.100"}) {
+		t.Errorf("expected only eth0.100 to be down, got %v", down)
+	}
+}
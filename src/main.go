@@ -20,7 +20,10 @@ package main
 import (
 	"fmt"
 	log "github.com/Sirupsen/logrus"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 	"strings"
 )
@@ -70,6 +73,25 @@ func (p *Pulse) getMemberlist() (*Memberlist) {
 	return pulse.Server.Memberlist
 }
 
+/**
+ * Gracefully stop PulseHA. If we are the active member the
+ * active role is handed over before our servers are stopped.
+ */
+func (p *Pulse) shutdown() {
+	log.Info("Shutting down PulseHA..")
+	if gconf.ClusterCheck() {
+		if gconf.ClusterTotal() > 1 {
+			p.getMemberlist().handover()
+		}
+		if p.Server.Server != nil {
+			p.Server.shutdown()
+		}
+	}
+	p.CLI.shutdown()
+	localChecks.Stop()
+	log.Info("PulseHA stopped")
+}
+
 type PulseLogFormat struct {}
 
 
@@ -95,6 +117,8 @@ func createPulse() *Pulse {
 	gconf.Load()
 	// Validate the config
 	gconf.Validate()
+	// Load the persisted cluster term
+	term.Load()
 	// Set the logging level
 	setLogLevel(gconf.Logging.Level)
 	// Define new Memberlist
@@ -130,12 +154,15 @@ func main() {
 	pulse = createPulse()
 	// Load plugins
 	pulse.Plugins.Setup()
-	// Setup wait group
-	var wg sync.WaitGroup
-	wg.Add(1)
+	// Start our health checks
+	startChecks()
+	// Listen for shutdown signals
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
 	// Setup cli
 	go pulse.CLI.Setup()
 	// Setup server
 	go pulse.Server.Setup()
-	wg.Wait()
+	<-signals
+	pulse.shutdown()
 }
@@ -0,0 +1,72 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"errors"
+	log "github.com/Sirupsen/logrus"
+)
+
+/**
+ * Returns true if the whole cluster is in maintenance mode.
+ */
+func clusterMaintenance() bool {
+	return gconf.GetConfig().Pulse.Maintenance
+}
+
+/**
+ * Returns true if the node is in maintenance mode.
+ */
+func nodeInMaintenance(hostname string) bool {
+	node, err := NodeGetByName(hostname)
+	if err != nil {
+		return false
+	}
+	return node.Maintenance
+}
+
+/**
+ * Returns true if automatic failover must not be performed by the node.
+ */
+func failOverFrozen(hostname string) bool {
+	return clusterMaintenance() || nodeInMaintenance(hostname)
+}
+
+/**
+ * Enable or disable maintenance mode for a node or for the
+ * whole cluster when no node is specified.
+ */
+func SetMaintenance(hostname string, enable bool) error {
+	if hostname == "" {
+		log.Infof("Cluster maintenance mode set to %t", enable)
+		gconf.Lock()
+		gconf.Pulse.Maintenance = enable
+		gconf.Unlock()
+		return nil
+	}
+	if !NodeExists(hostname) {
+		return errors.New("unable to set maintenance mode as the node does not exist")
+	}
+	log.Infof("Maintenance mode for %s set to %t", hostname, enable)
+	gconf.Lock()
+	node := gconf.Nodes[hostname]
+	node.Maintenance = enable
+	gconf.Nodes[hostname] = node
+	gconf.Unlock()
+	return nil
+}
@@ -0,0 +1,46 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import "testing"
+
+func TestSetMaintenance(t *testing.T) {
+	gconf.SetConfig(Config{
+		Nodes: map[string]Node{
+			"node1": {},
+			"node2": {},
+		},
+	})
+	defer gconf.SetConfig(Config{})
+	if err := SetMaintenance("node3", true); err == nil {
+		t.Error("expected an error for an unknown node")
+	}
+	if err := SetMaintenance("node2", true); err != nil {
+		t.Fatal(err)
+	}
+	if !nodeInMaintenance("node2") || nodeInMaintenance("node1") {
+		t.Error("only node2 should be in maintenance")
+	}
+	if failOverFrozen("node1") || !failOverFrozen("node2") {
+		t.Error("failover should only be frozen for node2")
+	}
+	SetMaintenance("", true)
+	if !clusterMaintenance() || !failOverFrozen("node1") {
+		t.Error("cluster maintenance should freeze failover for every node")
+	}
+}
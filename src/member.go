@@ -26,7 +26,6 @@ import (
 	"time"
 	"fmt"
 	"github.com/Syleron/PulseHA/src/utils"
-	"math"
 )
 
 /**
@@ -43,8 +42,18 @@ type Member struct {
 	Latency             string
 	// Determines if the health check is being made.
 	HCBusy bool
+	// The last cluster term known for the member
+	Term uint64
+	// Whether an interface carrying floating IPs is down on the member
+	LinkDown bool
+	// Whether a tracked check is failing or recently failed on the member
+	Unhealthy bool
+	// The weighted health score of the member
+	Score int32
 	// The client for the member that is used to send GRPC calls
 	Client
+	// The network paths health checks are sent to the member over
+	paths []*heartbeatPath
 	// The mutex to lock the member object
 	sync.Mutex
 }
@@ -108,6 +117,78 @@ func (m *Member) getLatency() string {
 	return m.Latency
 }
 
+/**
+
+*/
+func (m *Member) setTerm(term uint64) {
+	m.Lock()
+	defer m.Unlock()
+	m.Term = term
+}
+
+/**
+
+*/
+func (m *Member) getTerm() uint64 {
+	m.Lock()
+	defer m.Unlock()
+	return m.Term
+}
+
+/**
+
+*/
+func (m *Member) setLinkDown(down bool) {
+	m.Lock()
+	defer m.Unlock()
+	m.LinkDown = down
+}
+
+/**
+
+*/
+func (m *Member) getLinkDown() bool {
+	m.Lock()
+	defer m.Unlock()
+	return m.LinkDown
+}
+
+/**
+
+*/
+func (m *Member) setScore(score int32) {
+	m.Lock()
+	defer m.Unlock()
+	m.Score = score
+}
+
+/**
+
+*/
+func (m *Member) getScore() int32 {
+	m.Lock()
+	defer m.Unlock()
+	return m.Score
+}
+
+/**
+
+*/
+func (m *Member) setUnhealthy(unhealthy bool) {
+	m.Lock()
+	defer m.Unlock()
+	m.Unhealthy = unhealthy
+}
+
+/**
+
+*/
+func (m *Member) getUnhealthy() bool {
+	m.Lock()
+	defer m.Unlock()
+	return m.Unhealthy
+}
+
 /**
   Set the last time this member received a health check
 */
@@ -158,10 +239,13 @@ func (m *Member) getStatus() proto.MemberStatus_Status {
 Set member status
 */
 func (m *Member) setStatus(status proto.MemberStatus_Status) {
-	log.Debug("Member:setStatus() " + m.getHostname() + " status set to " + status.String() + " called by " + MyCaller())
+	hostname := m.getHostname()
+	log.Debug("Member:setStatus() " + hostname + " status set to " + status.String() + " called by " + MyCaller())
 	m.Lock()
-	defer m.Unlock()
+	previous := m.Status
 	m.Status = status
+	m.Unlock()
+	flaps.record(hostname, previous, status, time.Now(), flapWindow())
 }
 
 /**
@@ -191,22 +275,49 @@ Close the client connection
 func (m *Member) Close() {
 	log.Debug("Member:Close() Connection closed")
 	m.Client.Close()
+	m.Lock()
+	paths := m.paths
+	m.Unlock()
+	for _, path := range paths {
+		path.close()
+	}
 }
 
 /**
-Active function - Send GRPC health check to current member
+Active function - Send GRPC health check to current member over each of its heartbeat paths.
+The member is only considered unreachable when every path fails.
 */
 func (m *Member) sendHealthCheck(data *proto.PulseHealthCheck) (interface{}, error) {
-	if m.Connection == nil {
-		return nil, errors.New("unable to send health check as member connection has not been initiated")
+	paths := m.syncPaths()
+	if len(paths) == 0 {
+		return nil, errors.New("unable to send health check as member has no heartbeat paths")
+	}
+	responses := make([]*proto.PulseHealthCheck, len(paths))
+	latencies := make([]time.Duration, len(paths))
+	errs := make([]error, len(paths))
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		wg.Add(1)
+		go func(i int, path *heartbeatPath) {
+			defer wg.Done()
+			responses[i], latencies[i], errs[i] = path.send(m.getHostname(), data)
+		}(i, path)
 	}
-	startTime := time.Now()
-	r, err := m.Send(SendHealthCheck, data)
+	wg.Wait()
 	// This is a record for the active appliance to know when it was last sent/received!
 	m.setLastHCResponse(time.Now())
-	elapsed := fmt.Sprint(time.Since(startTime).Round(time.Millisecond))
-	m.setLatency(elapsed)
-	return r, err
+	// Go with the fastest path that delivered
+	best := -1
+	for i := range paths {
+		if errs[i] == nil && (best < 0 || latencies[i] < latencies[best]) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return nil, errs[0]
+	}
+	m.setLatency(fmt.Sprint(latencies[best].Round(time.Millisecond)))
+	return responses[best], nil
 }
 
 /**
@@ -214,14 +325,41 @@ Send health check via a go routine and mark the HC busy/not
  */
 func (m *Member) routineHC(data *proto.PulseHealthCheck) {
 	m.setHCBusy(true)
-	_, err := m.sendHealthCheck(data)
+	r, err := m.sendHealthCheck(data)
 	if err != nil {
 		m.Close()
 		m.setStatus(proto.MemberStatus_UNAVAILABLE) // This may not be required
+	} else {
+		response := r.(*proto.PulseHealthCheck)
+		m.setTerm(response.Term)
+		m.setLinkDown(response.LinkDown)
+		m.setUnhealthy(response.Unhealthy)
+		m.setScore(response.Score)
+		// A higher term means we have been superseded so yield
+		if term.Observe(response.Term) {
+			log.Warn(m.getHostname() + " responded with a higher term. Yielding the active role")
+			if localMember, err := pulse.getMemberlist().getLocalMember(); err == nil {
+				localMember.makePassive()
+			}
+		}
 	}
 	m.setHCBusy(false)
 }
 
+/**
+Returns why the member should not take over as a last resort when no other
+member can, or an empty string if it can
+*/
+func (m *Member) takeOverBlocked() string {
+	if m.getLinkDown() {
+		return "our floating IP interfaces are down"
+	}
+	if m.getUnhealthy() {
+		return "our tracked checks are failing"
+	}
+	return ""
+}
+
 /*
 	Make the node active (bring up its groups)
 */
@@ -229,16 +367,21 @@ func (m *Member) makeActive() bool {
 	log.Debugf("Member:makeActive() Making %s active", m.getHostname())
 	// Make ourself active if we are refering to ourself
 	if m.getHostname() == gconf.getLocalNode() {
+		// Every promotion starts a new term
+		m.setTerm(term.Increment())
 		makeMemberActive()
 		// Reset vars
 		m.setLatency("")
 		m.setLastHCResponse(time.Time{})
 		m.setStatus(proto.MemberStatus_ACTIVE)
+		pulse.getMemberlist().resetActiveMonitors()
 		// Start performing health checks
 		log.Debug("Member:PromoteMember() Starting client connections monitor")
-		go utils.Scheduler(pulse.Server.Memberlist.monitorClientConns, 1*time.Second)
+		go utils.DynamicScheduler(pulse.Server.Memberlist.monitorClientConns, healthCheckInterval)
 		log.Debug("Member:PromoteMember() Starting health check handler")
-		go utils.Scheduler(pulse.Server.Memberlist.addHealthCheckHandler, 1*time.Second)
+		go utils.DynamicScheduler(pulse.Server.Memberlist.addHealthCheckHandler, healthCheckInterval)
+		log.Debug("Member:PromoteMember() Starting preemption monitor")
+		go utils.DynamicScheduler(pulse.Server.Memberlist.monitorPreemption, healthCheckInterval)
 	} else {
 		// TODO: Handle the closing of this connection
 		m.Connect()
@@ -246,6 +389,7 @@ func (m *Member) makeActive() bool {
 			SendPromote,
 			&proto.PulsePromote{
 				Member: m.getHostname(),
+				Term:   term.Get(),
 			})
 		// Handle if we have an error
 		if err != nil {
@@ -272,7 +416,7 @@ func (m *Member) makePassive() bool {
 			m.setStatus(proto.MemberStatus_PASSIVE)
 			// Start the scheduler
 			log.Debug("Member:makePassive() Starting the monitor received health checks scheduler " + m.getHostname())
-			go utils.Scheduler(m.monitorReceivedHCs, 10000*time.Millisecond)
+			go utils.DynamicScheduler(m.monitorReceivedHCs, monitorInterval)
 		}
 	} else {
 		// TODO: Handle the closing of this connection
@@ -281,6 +425,7 @@ func (m *Member) makePassive() bool {
 			SendMakePassive,
 			&proto.PulsePromote{
 				Member:  m.getHostname(),
+				Term:    term.Get(),
 			})
 		if err != nil {
 			log.Error(err)
@@ -334,24 +479,51 @@ func (m *Member) monitorReceivedHCs() bool {
 		return true
 	}
 	// calculate elapsed time
-	elapsed := math.Floor(float64(time.Since(m.getLastHCResponse()).Seconds()))
+	elapsed := time.Since(m.getLastHCResponse())
 	// determine if we might need to failover
-	if int(elapsed) > 0 && int(elapsed)%4 == 0 {
+	if elapsed >= failOverWarning() && elapsed < failOverLimit() {
 		log.Warning("No health checks are being made.. Perhaps a failover is required?")
 	}
 	// has our threshold been met? Failover?
-	//log.Info(elapsed)
-	if int(elapsed) >= 10 {
+	if elapsed >= failOverLimit() {
+		// Automatic failover is disabled while in maintenance
+		if failOverFrozen(gconf.getLocalNode()) {
+			log.Warn("Failover threshold reached but maintenance mode is enabled. Not failing over")
+			return false
+		}
+		if failOverDamped() {
+			log.Warn("Failover threshold reached but the active role has been flapping. Not failing over")
+			return false
+		}
 		log.Debug("Member:monitorReceivedHCs() Performing Failover..")
-		var addHCSuccess bool = false
-		// TODO: Perform additional health checks plugin stuff HERE
-		if !addHCSuccess {
+		// Perform additional health checks using our loaded plugins
+		if pulse.Plugins.activeFailed() {
 			log.Warn("Additional health checks have failed.")
+			// get our current active member
+			activeHostname, activeMember := pulse.getMemberlist().getActiveMember()
+			// Make sure the majority of the cluster agrees before we do anything
+			if !pulse.getMemberlist().quorumFailover(activeHostname) {
+				log.Warn("Unable to obtain quorum for failover. Perhaps we are the ones that are partitioned?")
+				m.setLastHCResponse(time.Now())
+				return false
+			}
 			// Nothing has worked.. assume the master has failed. Fail over.
 			member, err := pulse.getMemberlist().getNextActiveMember()
 			// no new active appliance was found
 			if err != nil {
+				// There is no point taking over if we cannot carry the floating IPs either
+				if reason := m.takeOverBlocked(); reason != "" {
+					log.Warn("Unable to find new active member and " + reason + ". Not failing over")
+					m.setLastHCResponse(time.Now())
+					return false
+				}
 				log.Warn("unable to find new active member.. we are now the active")
+				// make sure the old active has released its IPs
+				if !pulse.Plugins.fence(activeHostname) {
+					log.Error("Aborting failover as the active member could not be fenced")
+					m.setLastHCResponse(time.Now())
+					return false
+				}
 				// make ourself active as no new active can be found apparently
 				m.makeActive()
 				return true
@@ -362,8 +534,12 @@ func (m *Member) monitorReceivedHCs() bool {
 				m.setLastHCResponse(time.Now())
 				return false
 			}
-			// get our current active member
-			_, activeMember := pulse.getMemberlist().getActiveMember()
+			// make sure the old active has released its IPs
+			if !pulse.Plugins.fence(activeHostname) {
+				log.Error("Aborting failover as the active member could not be fenced")
+				m.setLastHCResponse(time.Now())
+				return false
+			}
 			// If we have an active appliance mark it unavailable
 			if activeMember != nil {
 				activeMember.setStatus(proto.MemberStatus_UNAVAILABLE)
@@ -375,6 +551,7 @@ func (m *Member) monitorReceivedHCs() bool {
 			log.Info("Local node is now active")
 			return true
 		} else {
+			log.Info("Additional health checks have passed. The active member appears to be alive.")
 			m.setLastHCResponse(time.Now())
 		}
 	}
@@ -16,3 +16,21 @@
    along with this program.  If not, see <http://www.gnu.org/licenses/>.
 */
 package main
+
+import "testing"
+
+func TestTakeOverBlocked(t *testing.T) {
+	member := &Member{Hostname: "node1"}
+	if reason := member.takeOverBlocked(); reason != "" {
+		t.Errorf("a healthy member should be able to take over, got %q", reason)
+	}
+	member.setUnhealthy(true)
+	if member.takeOverBlocked() == "" {
+		t.Error("a member failing its tracked checks should not take over")
+	}
+	member.setUnhealthy(false)
+	member.setLinkDown(true)
+	if member.takeOverBlocked() == "" {
+		t.Error("a member with its floating IP interfaces down should not take over")
+	}
+}
@@ -33,6 +33,23 @@ import (
  */
 type Memberlist struct {
 	Members []*Member
+	// The last time the active member could reach a majority of the cluster
+	lastQuorum time.Time
+	// The higher priority member waiting to preempt us and since when
+	preemptCandidate string
+	preemptSince     time.Time
+	// The floating IP drift seen on the last reconcile
+	driftSeen map[string]bool
+	// The last time we tried to hand over because of a link failure
+	lastLinkHandover time.Time
+	// The last time we tried to hand over because of a failed tracked check
+	lastCheckHandover time.Time
+	// The last time we handed over to a member with a better health score
+	lastScoreHandover time.Time
+	// The owner of each group when running active/active
+	groupOwners map[string]string
+	// Numbers each round of health checks so passives can spot the copies sent over other paths
+	hcRound uint64
 	sync.Mutex
 }
 
@@ -81,6 +98,7 @@ func (m *Memberlist) MemberRemoveByName(hostname string) {
 	defer m.Unlock()
 	for i, member := range m.Members {
 		if member.getHostname() == hostname {
+			member.Close()
 			m.Members = append(m.Members[:i], m.Members[i+1:]...)
 		}
 	}
@@ -148,14 +166,18 @@ func (m *Memberlist) Setup() {
 			// We are the only member in the cluster so
 			// we are assume that we are now the active appliance.
 			m.PromoteMember(gconf.getLocalNode())
+			// make sure every floating IP actually came up
+			reconcileLocal(m.ownsGroup)
 		} else {
+			// remove any floating IPs left behind by a previous run
+			reconcileLocal(func(group string) bool { return false })
 			// come up passive and monitoring health checks
 			localMember := m.GetMemberByHostname(gconf.getLocalNode())
 			//localMember.setLastHCResponse(time.Now().Add(time.Duration(10) * time.Second))
 			localMember.setLastHCResponse(time.Now())
 			localMember.setStatus(p.MemberStatus_PASSIVE)
 			log.Debug("Memberlist:Setup() - starting the monitor received health checks scheduler")
-			go utils.Scheduler(localMember.monitorReceivedHCs, 2000*time.Millisecond)
+			go utils.DynamicScheduler(localMember.monitorReceivedHCs, monitorInterval)
 		}
 	}
 }
@@ -214,6 +236,14 @@ Promote a member within the memberlist to become the active
 node
 */
 func (m *Memberlist) PromoteMember(hostname string) error {
+	// Without a hostname we promote the best member available
+	if hostname == "" {
+		next, err := m.getNextActiveMember()
+		if err != nil {
+			return errors.New("unable to promote as no eligible passive member was found")
+		}
+		hostname = next.getHostname()
+	}
 	log.Debug("Memberlist:PromoteMember() Memberlist promoting " + hostname + " as active member..")
 	// Inform everyone in the cluster that a specific node is now the new active
 	// Demote if old active is no longer active. promote if the passive is the new active.
@@ -252,7 +282,9 @@ func (m *Memberlist) PromoteMember(hostname string) error {
 	// make the hostname the new active
 	success := member.makeActive()
 	// make new node active
-	if !success {
+	if !success && activeMember == nil {
+		log.Warningf("Failed to promote %s to active", member.getHostname())
+	} else if !success {
 		log.Warningf("Failed to promote %s to active. Falling back to %s", member.getHostname(), activeMember.getHostname())
 		// Somethings gone wrong.. attempt to make the previous active - active again.
 		success := activeMember.makeActive()
@@ -286,8 +318,13 @@ func (m *Memberlist) monitorClientConns() bool {
 			continue
 		}
 		member.Connect()
-		log.Debug(member.Hostname + " connection status is " + member.Connection.GetState().String())
-		switch member.Connection.GetState() {
+		state := member.Connection.GetState()
+		log.Debug(member.Hostname + " connection status is " + state.String())
+		// The member is still alive as long as one of its heartbeat paths is up
+		if state != connectivity.Ready && member.heartbeatReachable() {
+			state = connectivity.Ready
+		}
+		switch state {
 		case connectivity.Idle:
 		case connectivity.Ready:
 			member.setStatus(p.MemberStatus_PASSIVE)
@@ -295,9 +332,116 @@ func (m *Memberlist) monitorClientConns() bool {
 			member.setStatus(p.MemberStatus_UNAVAILABLE)
 		}
 	}
+	// Make sure we can still reach a majority of the cluster
+	if !m.monitorQuorum() && !clusterMaintenance() {
+		log.Warn("Unable to reach a majority of the cluster. Demoting ourself to passive to prevent split brain")
+		member.makePassive()
+		return true
+	}
 	return false
 }
 
+/**
+Determine whether quorum is required for failover decisions.
+Note: A two node cluster can never form a majority without its peer so quorum only applies to three or more nodes.
+*/
+func quorumRequired() bool {
+	return gconf.ClusterTotal() >= 3
+}
+
+/**
+Returns true if the total votes form a majority of the configured cluster
+*/
+func hasQuorum(votes, total int) bool {
+	return votes > total/2
+}
+
+/**
+Active function - Returns false once we have been unable to reach a majority of
+the cluster for longer than the failover threshold.
+*/
+func (m *Memberlist) monitorQuorum() bool {
+	if !quorumRequired() {
+		return true
+	}
+	// Count ourselves
+	reachable := 1
+	for _, member := range m.Members {
+		if member.getHostname() == gconf.getLocalNode() {
+			continue
+		}
+		if member.getStatus() == p.MemberStatus_PASSIVE {
+			reachable++
+		}
+	}
+	m.Lock()
+	defer m.Unlock()
+	if hasQuorum(reachable, gconf.ClusterTotal()) || m.lastQuorum.IsZero() {
+		m.lastQuorum = time.Now()
+		return true
+	}
+	log.Warningf("Only %d of %d members are reachable", reachable, gconf.ClusterTotal())
+	return time.Since(m.lastQuorum) < failOverLimit()
+}
+
+/**
+Reset the state of the active monitors. Called whenever we become the active member.
+*/
+func (m *Memberlist) resetActiveMonitors() {
+	m.Lock()
+	defer m.Unlock()
+	m.lastQuorum = time.Time{}
+	m.preemptCandidate = ""
+	m.preemptSince = time.Time{}
+}
+
+/**
+Passive function - Ask the rest of the cluster whether they agree that the active
+member is unreachable. Returns true if a majority agrees.
+*/
+func (m *Memberlist) quorumFailover(activeHostname string) bool {
+	if !quorumRequired() {
+		return true
+	}
+	// We have already voted for ourselves
+	votes := 1
+	for _, member := range m.Members {
+		if member.getHostname() == gconf.getLocalNode() || member.getHostname() == activeHostname {
+			continue
+		}
+		if err := member.Connect(); err != nil {
+			continue
+		}
+		r, err := member.Send(SendQuorumVote, &p.PulseQuorum{
+			Candidate: gconf.getLocalNode(),
+			Active:    activeHostname,
+		})
+		if err != nil {
+			log.Debugf("Memberlist:quorumFailover() Unable to get vote from %s: %s", member.getHostname(), err.Error())
+			continue
+		}
+		if vote := r.(*p.PulseQuorum); vote.Success && vote.Unreachable {
+			votes++
+		}
+	}
+	log.Infof("Failover quorum vote: %d of %d members agree the active is unreachable", votes, gconf.ClusterTotal())
+	return hasQuorum(votes, gconf.ClusterTotal())
+}
+
+/**
+Determine whether the active member is unreachable from the point of view of the local member
+*/
+func (m *Memberlist) activeUnreachable(localMember *Member, activeHostname string) bool {
+	switch localMember.getStatus() {
+	case p.MemberStatus_ACTIVE:
+		// We are the active and we are clearly not dead
+		return false
+	case p.MemberStatus_PASSIVE:
+		return time.Since(localMember.getLastHCResponse()) >= failOverLimit()
+	}
+	return true
+}
+
 /**
 Send health checks to users who have a healthy connection
 */
@@ -312,18 +456,38 @@ func (m *Memberlist) addHealthCheckHandler() bool{
 		log.Debug("Memberlist:addHealthCheckHandler() Health check handler has stopped as it seems we are no longer active")
 		return true
 	}
+	// As the active we decide who owns each group
+	if activeActive() {
+		m.assignGroups()
+	}
+	m.Lock()
+	m.hcRound++
+	round := m.hcRound
+	m.Unlock()
 	for _, member := range m.Members {
 		if member.getHostname() == gconf.getLocalNode() {
 			continue
 		}
 		if !member.getHCBusy() && member.getStatus() == p.MemberStatus_PASSIVE {
-			memberlist := new(p.PulseHealthCheck)
+			memberlist := &p.PulseHealthCheck{
+				Term:   term.Get(),
+				Owners: m.groupOwnersProto(),
+				Round:  round,
+			}
 			for _, member := range m.Members {
+				memberTerm := member.getTerm()
+				if member.getHostname() == gconf.getLocalNode() {
+					memberTerm = term.Get()
+				}
 				newMember := &p.MemberlistMember{
 					Hostname: member.getHostname(),
 					Status:   member.getStatus(),
 					Latency: member.getLatency(),
 					LastReceived: member.getLastHCResponse().Format(time.RFC1123),
+					Term: memberTerm,
+					LinkDown: member.getLinkDown(),
+					Unhealthy: member.getUnhealthy(),
+					Score: member.getScore(),
 				}
 				memberlist.Memberlist = append(memberlist.Memberlist, newMember)
 			}
@@ -364,6 +528,13 @@ func (m *Memberlist) update(memberlist []*p.MemberlistMember) {
 			if member.GetHostname() == localMember.getHostname() {
 				localMember.setStatus(member.Status)
 				localMember.setLatency(member.Latency)
+				localMember.setTerm(member.Term)
+				// we know our own link and check state best
+				if member.GetHostname() != gconf.getLocalNode() {
+					localMember.setLinkDown(member.LinkDown)
+					localMember.setUnhealthy(member.Unhealthy)
+					localMember.setScore(member.Score)
+				}
 				// our local last received has priority
 				if member.GetHostname() != gconf.getLocalNode() {
 					tym, _ := time.Parse(time.RFC1123, member.LastReceived)
@@ -377,18 +548,36 @@ func (m *Memberlist) update(memberlist []*p.MemberlistMember) {
 
 /**
 Calculate who's next to become active in the memberlist
+Note: The eligible passive member with the highest health score is chosen.
+Members with the same score are considered in order of their configured priority.
 */
 func (m *Memberlist) getNextActiveMember() (*Member, error) {
-	for hostname, _ := range gconf.Nodes {
+	var best *Member
+	for _, hostname := range NodesByPriority() {
 		member := m.GetMemberByHostname(hostname)
 		if member == nil {
 			panic("Memberlist:getNextActiveMember() Cannot get member by hostname " + hostname)
 		}
-		if member.getStatus() == p.MemberStatus_PASSIVE {
-			log.Debug("Memberlist:getNextActiveMember() " + member.getHostname() + " is the new active appliance")
-			return member, nil
+		if nodeInMaintenance(hostname) {
+			log.Debug("Memberlist:getNextActiveMember() Skipping " + hostname + " as it is in maintenance")
+			continue
+		}
+		if member.getLinkDown() {
+			log.Debug("Memberlist:getNextActiveMember() Skipping " + hostname + " as its floating IP interfaces are down")
+			continue
+		}
+		if member.getUnhealthy() {
+			log.Debug("Memberlist:getNextActiveMember() Skipping " + hostname + " as its tracked checks are failing")
+			continue
+		}
+		if member.getStatus() == p.MemberStatus_PASSIVE && (best == nil || member.getScore() > best.getScore()) {
+			best = member
 		}
 	}
+	if best != nil {
+		log.Debug("Memberlist:getNextActiveMember() " + best.getHostname() + " is the new active appliance")
+		return best, nil
+	}
 	return &Member{}, errors.New("Memberlist:getNextActiveMember() No new active member found")
 }
 
@@ -410,5 +599,107 @@ Reset the memberlist when we are no longer in a cluster.
 func (m *Memberlist) reset() {
 	m.Lock()
 	defer m.Unlock()
+	for _, member := range m.Members {
+		member.Close()
+	}
 	m.Members = []*Member{}
-}
\ No newline at end of file
+}
+
+/**
+Active function - Hand the active role back to a recovered higher priority member
+once it has been available for the configured hold-down delay.
+*/
+func (m *Memberlist) monitorPreemption() bool {
+	member, err := m.getLocalMember()
+	if err != nil {
+		log.Debug("Memberlist:monitorPreemption() Preemption monitoring has stopped as it seems we are no longer in a cluster")
+		return true
+	}
+	if member.getStatus() != p.MemberStatus_ACTIVE {
+		log.Debug("Memberlist:monitorPreemption() Preemption monitoring has stopped as we are no longer active")
+		return true
+	}
+	config := gconf.GetConfig()
+	if !config.Pulse.Preempt || config.Pulse.Maintenance || failOverDamped() {
+		return false
+	}
+	candidate := ""
+	for _, hostname := range NodesByPriority() {
+		if hostname == gconf.getLocalNode() {
+			break
+		}
+		if nodeInMaintenance(hostname) {
+			continue
+		}
+		// Don't hand over to a member that is less healthy than we are
+		if peer := m.GetMemberByHostname(hostname); peer != nil && peer.getStatus() == p.MemberStatus_PASSIVE &&
+			!peer.getUnhealthy() && peer.getScore() >= member.getScore() {
+			candidate = hostname
+			break
+		}
+	}
+	m.Lock()
+	if candidate != m.preemptCandidate {
+		m.preemptCandidate = candidate
+		m.preemptSince = time.Now()
+	}
+	since := m.preemptSince
+	m.Unlock()
+	if candidate == "" || time.Since(since) < time.Duration(config.Pulse.PreemptDelay)*time.Second {
+		return false
+	}
+	log.Infof("Higher priority member %s has been available for %ds. Handing over the active role", candidate, config.Pulse.PreemptDelay)
+	if err := m.PromoteMember(candidate); err != nil {
+		log.Warningf("Unable to preempt to %s: %s", candidate, err.Error())
+		return false
+	}
+	return true
+}
+
+/**
+Take the active role back after a failed handover so the cluster is not left without an active
+*/
+func (m *Memberlist) reclaimActive(localMember *Member) {
+	log.Warn("Handover failed. Taking the active role back")
+	localMember.makeActive()
+}
+
+/**
+Active function - Hand the active role over to the next available member.
+Used when the daemon is shutting down so planned restarts do not wait for a failover.
+*/
+func (m *Memberlist) handover() bool {
+	localMember, err := m.getLocalMember()
+	if err != nil || localMember.getStatus() != p.MemberStatus_ACTIVE {
+		return true
+	}
+	successor, err := m.getNextActiveMember()
+	if err != nil {
+		log.Warn("Unable to hand over the active role as no other member is available")
+		return false
+	}
+	log.Info("Handing over the active role to " + successor.getHostname())
+	// Release our groups first so the successor can claim them
+	localMember.makePassive()
+	if err := successor.Connect(); err != nil {
+		log.Errorf("Unable to connect to %s to hand over the active role: %s", successor.getHostname(), err.Error())
+		m.reclaimActive(localMember)
+		return false
+	}
+	r, err := successor.Send(SendPromote, &p.PulsePromote{
+		Member: successor.getHostname(),
+		Term:   term.Get(),
+	})
+	if err != nil {
+		log.Errorf("Unable to hand over the active role to %s: %s", successor.getHostname(), err.Error())
+		m.reclaimActive(localMember)
+		return false
+	}
+	if !r.(*p.PulsePromote).Success {
+		log.Errorf("%s failed to become active", successor.getHostname())
+		m.reclaimActive(localMember)
+		return false
+	}
+	log.Info(successor.getHostname() + " is now the active member")
+	return true
+}
@@ -16,3 +16,23 @@
    along with this program.  If not, see <http://www.gnu.org/licenses/>.
 */
 package main
+
+import "testing"
+
+func TestHasQuorum(t *testing.T) {
+	tests := []struct {
+		votes, total int
+		want         bool
+	}{
+		{1, 3, false},
+		{2, 3, true},
+		{2, 4, false},
+		{3, 4, true},
+		{3, 5, true},
+	}
+	for _, tt := range tests {
+		if got := hasQuorum(tt.votes, tt.total); got != tt.want {
+			t.Errorf("hasQuorum(%d, %d) = %t, want %t", tt.votes, tt.total, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package netUtils
+
+import "errors"
+
+/**
+ * Errors returned when manipulating network addresses
+ */
+var (
+	ErrAddressExists    = errors.New("address already exists")
+	ErrAddressNotFound  = errors.New("address does not exist")
+	ErrNoSuchDevice     = errors.New("no such network device")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrInvalidAddress   = errors.New("invalid address")
+	ErrNotSupported     = errors.New("not supported on this platform")
+	ErrRouteExists      = errors.New("route already exists")
+	ErrRouteNotFound    = errors.New("route does not exist")
+	ErrInvalidRoute     = errors.New("invalid route")
+	ErrInvalidLink      = errors.New("invalid link specification")
+)
+
+/**
+ * AddrError records a failed address operation along with its cause.
+ * The cause is one of the errors above when it is known.
+ */
+type AddrError struct {
+	Op      string
+	Iface   string
+	Address string
+	Err     error
+}
+
+func (e *AddrError) Error() string {
+	msg := "unable to " + e.Op
+	if e.Address != "" {
+		msg += " " + e.Address
+	}
+	if e.Iface != "" {
+		msg += " on interface " + e.Iface
+	}
+	return msg + ": " + e.Err.Error()
+}
+
+func (e *AddrError) Unwrap() error {
+	return e.Err
+}
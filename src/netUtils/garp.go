@@ -0,0 +1,58 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package netUtils
+
+import (
+	"encoding/binary"
+	"net"
+)
+
+/**
+ * Ethernet and ARP constants
+ */
+const (
+	etherTypeARP  = 0x0806
+	etherTypeIPv4 = 0x0800
+	arpRequest    = 1
+	// Frames shorter than this are padded
+	minEthernetFrame = 60
+)
+
+/**
+ * Build a gratuitous ARP request frame announcing that ip lives at mac.
+ * Both the sender and target protocol address are the announced IP.
+ */
+func garpFrame(mac net.HardwareAddr, ip net.IP) []byte {
+	frame := make([]byte, minEthernetFrame)
+	// Ethernet header
+	copy(frame[0:6], net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	copy(frame[6:12], mac)
+	binary.BigEndian.PutUint16(frame[12:14], etherTypeARP)
+	// ARP payload
+	arp := frame[14:]
+	binary.BigEndian.PutUint16(arp[0:2], 1)
+	binary.BigEndian.PutUint16(arp[2:4], etherTypeIPv4)
+	arp[4] = 6
+	arp[5] = 4
+	binary.BigEndian.PutUint16(arp[6:8], arpRequest)
+	copy(arp[8:14], mac)
+	copy(arp[14:18], ip.To4())
+	// Target hardware address is left empty
+	copy(arp[24:28], ip.To4())
+	return frame
+}
@@ -0,0 +1,46 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package netUtils
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestGarpFrame(t *testing.T) {
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+	ip := net.ParseIP("10.0.0.10")
+	frame := garpFrame(mac, ip)
+	if len(frame) != minEthernetFrame {
+		t.Fatalf("expected a %d byte frame, got %d", minEthernetFrame, len(frame))
+	}
+	if !bytes.Equal(frame[0:6], []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}) || !bytes.Equal(frame[6:12], mac) {
+		t.Errorf("unexpected ethernet addresses %v", frame[0:12])
+	}
+	if frame[12] != 0x08 || frame[13] != 0x06 {
+		t.Errorf("unexpected ether type %x%x", frame[12], frame[13])
+	}
+	arp := frame[14:]
+	if arp[7] != arpRequest {
+		t.Errorf("expected an ARP request, got %d", arp[7])
+	}
+	if !bytes.Equal(arp[8:14], mac) || !bytes.Equal(arp[14:18], ip.To4()) || !bytes.Equal(arp[24:28], ip.To4()) {
+		t.Errorf("unexpected ARP addresses %v", arp[8:28])
+	}
+}
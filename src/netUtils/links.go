@@ -0,0 +1,98 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package netUtils
+
+import (
+	"strconv"
+)
+
+/**
+ * Types of interface PulseHA can create for a group assignment
+ */
+const (
+	LinkVLAN    = "vlan"
+	LinkMacvlan = "macvlan"
+	LinkBond    = "bond"
+)
+
+/**
+ * Describes an interface PulseHA creates when a group is brought up and
+ * removes when it is brought down. VLAN and macvlan interfaces sit on a
+ * parent interface while a bond enslaves its slaves. The mode is the macvlan
+ * or bonding mode.
+ */
+type LinkSpec struct {
+	Type   string   `json:"type"`
+	Parent string   `json:"parent,omitempty"`
+	VLAN   int      `json:"vlan,omitempty"`
+	Slaves []string `json:"slaves,omitempty"`
+	Mode   string   `json:"mode,omitempty"`
+}
+
+var macvlanModes = []string{"private", "vepa", "bridge", "passthru"}
+
+var bondModes = []string{"balance-rr", "active-backup", "balance-xor", "broadcast", "802.3ad", "balance-tlb", "balance-alb"}
+
+/**
+ * Returns the name used for a VLAN interface when one is not given
+ */
+func VLANName(parent string, vlan int) string {
+	return parent + "." + strconv.Itoa(vlan)
+}
+
+/**
+ * Returns the interfaces the link is built on
+ */
+func (s LinkSpec) Lowers() []string {
+	if s.Type == LinkBond {
+		return s.Slaves
+	}
+	return []string{s.Parent}
+}
+
+/**
+ * Make sure the link can be created
+ */
+func (s LinkSpec) Validate() error {
+	switch s.Type {
+	case LinkVLAN:
+		if s.Parent == "" || s.VLAN < 1 || s.VLAN > 4094 {
+			return ErrInvalidLink
+		}
+	case LinkMacvlan:
+		if s.Parent == "" || (s.Mode != "" && !hasString(macvlanModes, s.Mode)) {
+			return ErrInvalidLink
+		}
+	case LinkBond:
+		if len(s.Slaves) == 0 || (s.Mode != "" && !hasString(bondModes, s.Mode)) {
+			return ErrInvalidLink
+		}
+	default:
+		return ErrInvalidLink
+	}
+	return nil
+}
+
+func hasString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package netUtils
+
+import (
+	"testing"
+)
+
+func TestLinkSpecValidate(t *testing.T) {
+	tests := []struct {
+		spec  LinkSpec
+		valid bool
+	}{
+		{LinkSpec{Type: LinkVLAN, Parent: "eth0", VLAN: 100}, true},
+		{LinkSpec{Type: LinkVLAN, Parent: "eth0"}, false},
+		{LinkSpec{Type: LinkVLAN, VLAN: 100}, false},
+		{LinkSpec{Type: LinkVLAN, Parent: "eth0", VLAN: 4095}, false},
+		{LinkSpec{Type: LinkMacvlan, Parent: "eth0"}, true},
+		{LinkSpec{Type: LinkMacvlan, Parent: "eth0", Mode: "bridge"}, true},
+		{LinkSpec{Type: LinkMacvlan, Parent: "eth0", Mode: "bogus"}, false},
+		{LinkSpec{Type: LinkBond, Slaves: []string{"eth1", "eth2"}, Mode: "802.3ad"}, true},
+		{LinkSpec{Type: LinkBond}, false},
+		{LinkSpec{Type: "bridge", Parent: "eth0"}, false},
+	}
+	for _, test := range tests {
+		if err := test.spec.Validate(); (err == nil) != test.valid {
+			t.Errorf("Validate(%+v) = %v, want valid %t", test.spec, err, test.valid)
+		}
+	}
+	if name := VLANName("eth0", 100); name != "eth0.100" {
+		t.Errorf("unexpected vlan interface name %s", name)
+	}
+}
@@ -0,0 +1,47 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package netUtils
+
+import (
+	"net"
+)
+
+/**
+ * ICMPv6 neighbour advertisement constants
+ */
+const (
+	icmpv6NeighborAdvertisement = 136
+	naFlagOverride              = 0x20
+	ndpOptTargetLinkAddress     = 2
+)
+
+/**
+ * Build an unsolicited neighbour advertisement ICMPv6 message for the target address.
+ * The checksum is left empty as the kernel calculates it for ICMPv6 sockets.
+ */
+func neighborAdvertisement(target net.IP, mac net.HardwareAddr) []byte {
+	msg := make([]byte, 24, 24+2+len(mac))
+	msg[0] = icmpv6NeighborAdvertisement
+	// Not solicited but override any existing cache entries
+	msg[4] = naFlagOverride
+	copy(msg[8:24], target.To16())
+	// Target link-layer address option. The length is in units of 8 bytes
+	msg = append(msg, ndpOptTargetLinkAddress, byte((2+len(mac)+7)/8))
+	msg = append(msg, mac...)
+	return msg
+}
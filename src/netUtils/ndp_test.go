@@ -0,0 +1,45 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package netUtils
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestNeighborAdvertisement(t *testing.T) {
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+	target := net.ParseIP("2001:db8::10")
+	msg := neighborAdvertisement(target, mac)
+	if len(msg) != 32 {
+		t.Fatalf("expected a 32 byte message, got %d", len(msg))
+	}
+	if msg[0] != icmpv6NeighborAdvertisement || msg[1] != 0 {
+		t.Errorf("unexpected type/code %d/%d", msg[0], msg[1])
+	}
+	if msg[4] != naFlagOverride {
+		t.Errorf("expected only the override flag, got %x", msg[4])
+	}
+	if !bytes.Equal(msg[8:24], target.To16()) {
+		t.Errorf("unexpected target address %v", net.IP(msg[8:24]))
+	}
+	if msg[24] != ndpOptTargetLinkAddress || msg[25] != 1 || !bytes.Equal(msg[26:], mac) {
+		t.Errorf("unexpected link-layer address option %v", msg[24:])
+	}
+}
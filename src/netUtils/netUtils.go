@@ -18,48 +18,21 @@
 package netUtils
 
 import (
-	"bytes"
 	"github.com/Syleron/PulseHA/src/utils"
 	"net"
-	"os"
-	"os/exec"
-	"strings"
 	log "github.com/Sirupsen/logrus"
-	"errors"
 )
 
-/**
- * Send Gratuitous ARP to automagically tell the router who has the new floating IP
- * NOTE: This function assumes the OS is LINUX and has "arping" installed.
- */
-func SendGARP(iface, ip string) bool {
-	if !ifaceExist(iface) {
-		log.Error("Unable to GARP as the network interface does not exist! Closing..")
-		os.Exit(1)
-	}
-	log.Debug("Sending gratuitous arp for " + ip + " on interface " + iface)
-	output, err := utils.Execute("arping", "-U", "-c", "5", "-I", iface, ip)
-	if err != nil {
-		return false
-	}
-	if output == "" {
-		return true
-	} else {
-		return false
-	}
-}
-
 /**
  * Checks to see what status a network interface is currently.
  * Possible responses are either up or down.
  */
-func netInterfaceStatus(iface string) bool {
-	_, err := utils.Execute("cat", "/sys/class/net/"+iface+"/operstate")
+func NetInterfaceStatus(iface string) bool {
+	up, err := InterfaceUp(iface)
 	if err != nil {
-		//return err.Error();
 		return false
 	}
-	return true
+	return up
 }
 
 /**
@@ -80,82 +53,20 @@ func ifaceExist(iface string) bool {
  * This function is to bring up a network interface
  */
 func BringIPup(iface, ip string) (bool, error) {
-	if !ifaceExist(iface) {
-		return false, errors.New("Unable to bring IP up as the network interface does not exist")
-	}
-	output, err := utils.Execute("ip", "ad", "ad", ip, "dev", iface)
-	// guessing
-	if err != nil {
-		return true, errors.New("Unable to bring up ip " + ip + " on interface " + iface + ". Perhaps it already exists?")
-	}
-
-	if output == "" {
-		return true, nil
-	} else {
-		return false, errors.New(output)
+	if err := AddAddress(iface, ip); err != nil {
+		return false, err
 	}
+	return true, nil
 }
 
 /**
  * This function is to bring down a network interface
  */
 func BringIPdown(iface, ip string) (bool, error) {
-	if !ifaceExist(iface) {
-		return false, errors.New("Unable to bring IP down as the network interface does not exist")
-	}
-	output, err := utils.Execute("ip", "ad", "del", ip, "dev", iface)
-	// guessing
-	if err != nil {
-		return true, errors.New("Unable to bring down ip " + ip + " on interface " + iface + ". Perhaps it doesn't exist?")
-	}
-	if output == "" {
-		return true, nil
-	} else {
+	if err := DeleteAddress(iface, ip); err != nil {
 		return false, err
 	}
-}
-
-/**
- * Perform a curl request to a web host.
- * This only returns a boolean based off the http status code received by the request.
- */
-func Curl(httpRequestURL string) bool {
-	output, err := utils.Execute("curl", "-s", "-o", "/dev/null", "-w", "\"%{http_code}\"", httpRequestURL)
-	if err != nil {
-		//log.Error("Http Curl request failed.")
-		return false
-	}
-	if output == "\"200\"" {
-		return true
-	} else {
-		return false
-	}
-}
-
-/**
- *
- */
-func ICMPIPv4(Ipv4Addr string) bool {
-	// Validate the IP address to ensure it's an IPv4 addr.
-	if err := utils.ValidIPAddress(Ipv4Addr); err != nil {
-		//log.Error("Invalid IPv4 address for ICMP check..")
-		return false
-	}
-	cmds := "ping -c 1 -W 1 " + Ipv4Addr + " &> /dev/null ; echo $?"
-	cmd := exec.Command("bash", "-c", cmds)
-	cmd.Stdin = strings.NewReader("some input")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	err := cmd.Run()
-	if err != nil {
-		//log.Error("ICMP request failed.")
-		return false
-	}
-	if strings.Contains(out.String(), "0") {
-		return true
-	} else {
-		return false
-	}
+	return true, nil
 }
 
 /**
@@ -175,7 +86,7 @@ func ArpScan(addrWSubnet string) string {
 func GetInterfaceNames() []string {
 	ifaces, err := net.Interfaces()
 	if err != nil {
-		log.Errorf("Error retrieving network interfaces: ", err)
+		log.Errorf("Error retrieving network interfaces: %s", err)
 	}
 	var interfaceNames []string
 	for _, iface := range ifaces {
@@ -190,7 +101,7 @@ func GetInterfaceNames() []string {
 func InterfaceExist(name string) bool {
 	ifaces, err := net.Interfaces()
 	if err != nil {
-		log.Errorf("Error retrieving network interfaces: ", err)
+		log.Errorf("Error retrieving network interfaces: %s", err)
 	}
 	for _, iface := range ifaces {
 		if iface.Name == name {
@@ -0,0 +1,392 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package netUtils
+
+import (
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"net"
+)
+
+/**
+ * Translate a netlink error into one of our errors where possible
+ */
+func netlinkError(op, iface, address string, err error) error {
+	if _, ok := err.(netlink.LinkNotFoundError); ok {
+		err = ErrNoSuchDevice
+	}
+	switch err {
+	case unix.EEXIST:
+		err = ErrAddressExists
+	case unix.EADDRNOTAVAIL:
+		err = ErrAddressNotFound
+	case unix.ENODEV:
+		err = ErrNoSuchDevice
+	case unix.EPERM, unix.EACCES:
+		err = ErrPermissionDenied
+	}
+	return &AddrError{Op: op, Iface: iface, Address: address, Err: err}
+}
+
+/**
+ * Add an address in CIDR notation to an interface
+ */
+func AddAddress(iface, address string) error {
+	link, err := netlink.LinkByName(iface)
+	if err != nil {
+		return netlinkError("add", iface, address, err)
+	}
+	addr, err := netlink.ParseAddr(address)
+	if err != nil {
+		return &AddrError{Op: "add", Iface: iface, Address: address, Err: ErrInvalidAddress}
+	}
+	// Floating IPv6 addresses must be usable straight away so skip duplicate address detection
+	if addr.IP.To4() == nil {
+		addr.Flags |= unix.IFA_F_NODAD
+	}
+	if err := netlink.AddrAdd(link, addr); err != nil {
+		return netlinkError("add", iface, address, err)
+	}
+	return nil
+}
+
+/**
+ * Remove an address in CIDR notation from an interface
+ */
+func DeleteAddress(iface, address string) error {
+	link, err := netlink.LinkByName(iface)
+	if err != nil {
+		return netlinkError("delete", iface, address, err)
+	}
+	addr, err := netlink.ParseAddr(address)
+	if err != nil {
+		return &AddrError{Op: "delete", Iface: iface, Address: address, Err: ErrInvalidAddress}
+	}
+	if err := netlink.AddrDel(link, addr); err != nil {
+		return netlinkError("delete", iface, address, err)
+	}
+	return nil
+}
+
+/**
+ * Create an interface from its spec and bring it up. Bond slaves are
+ * enslaved and brought up. Nothing is done if the interface already exists.
+ */
+func AddLink(name string, spec LinkSpec) error {
+	if _, err := netlink.LinkByName(name); err == nil {
+		return nil
+	}
+	if err := spec.Validate(); err != nil {
+		return &AddrError{Op: "add link", Iface: name, Err: err}
+	}
+	attrs := netlink.NewLinkAttrs()
+	attrs.Name = name
+	var link netlink.Link
+	switch spec.Type {
+	case LinkVLAN, LinkMacvlan:
+		parent, err := netlink.LinkByName(spec.Parent)
+		if err != nil {
+			return netlinkError("add link", spec.Parent, name, err)
+		}
+		attrs.ParentIndex = parent.Attrs().Index
+		if spec.Type == LinkVLAN {
+			link = &netlink.Vlan{LinkAttrs: attrs, VlanId: spec.VLAN}
+		} else {
+			link = &netlink.Macvlan{LinkAttrs: attrs, Mode: macvlanMode(spec.Mode)}
+		}
+	case LinkBond:
+		bond := netlink.NewLinkBond(attrs)
+		bond.Mode = netlink.BOND_MODE_ACTIVE_BACKUP
+		if spec.Mode != "" {
+			bond.Mode = netlink.StringToBondMode(spec.Mode)
+		}
+		link = bond
+	}
+	if err := netlink.LinkAdd(link); err != nil {
+		return netlinkError("add link", name, "", err)
+	}
+	if bond, ok := link.(*netlink.Bond); ok {
+		for _, name := range spec.Slaves {
+			if err := enslave(bond, name); err != nil {
+				netlink.LinkDel(link)
+				return err
+			}
+		}
+	}
+	if err := netlink.LinkSetUp(link); err != nil {
+		netlink.LinkDel(link)
+		return netlinkError("add link", name, "", err)
+	}
+	return nil
+}
+
+/**
+ * Add a slave to a bond. Slaves must be down while they are enslaved.
+ */
+func enslave(bond *netlink.Bond, name string) error {
+	slave, err := netlink.LinkByName(name)
+	if err != nil {
+		return netlinkError("enslave", name, "", err)
+	}
+	if err := netlink.LinkSetDown(slave); err != nil {
+		return netlinkError("enslave", name, "", err)
+	}
+	if err := netlink.LinkSetBondSlave(slave, bond); err != nil {
+		return netlinkError("enslave", name, "", err)
+	}
+	if err := netlink.LinkSetUp(slave); err != nil {
+		return netlinkError("enslave", name, "", err)
+	}
+	return nil
+}
+
+/**
+ * Returns the netlink macvlan mode. Defaults to private.
+ */
+func macvlanMode(mode string) netlink.MacvlanMode {
+	switch mode {
+	case "vepa":
+		return netlink.MACVLAN_MODE_VEPA
+	case "bridge":
+		return netlink.MACVLAN_MODE_BRIDGE
+	case "passthru":
+		return netlink.MACVLAN_MODE_PASSTHRU
+	}
+	return netlink.MACVLAN_MODE_PRIVATE
+}
+
+/**
+ * Remove an interface created by AddLink
+ */
+func DeleteLink(name string) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return netlinkError("delete link", name, "", err)
+	}
+	if err := netlink.LinkDel(link); err != nil {
+		return netlinkError("delete link", name, "", err)
+	}
+	return nil
+}
+
+/**
+ * Create a macvlan interface on the parent with the VRRP virtual MAC for the
+ * virtual router ID and bring it up. Returns the name of the interface.
+ * Nothing is done if the interface already exists. Removed with DeleteLink.
+ */
+func AddVMAC(parent string, vrid int) (string, error) {
+	name := VMACName(vrid)
+	if _, err := netlink.LinkByName(name); err == nil {
+		return name, nil
+	}
+	link, err := netlink.LinkByName(parent)
+	if err != nil {
+		return "", netlinkError("add virtual mac", parent, name, err)
+	}
+	vmac := &netlink.Macvlan{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:         name,
+			ParentIndex:  link.Attrs().Index,
+			HardwareAddr: VirtualMAC(vrid),
+		},
+		Mode: netlink.MACVLAN_MODE_PRIVATE,
+	}
+	if err := netlink.LinkAdd(vmac); err != nil {
+		return "", netlinkError("add virtual mac", parent, name, err)
+	}
+	if err := netlink.LinkSetUp(vmac); err != nil {
+		netlink.LinkDel(vmac)
+		return "", netlinkError("add virtual mac", parent, name, err)
+	}
+	return name, nil
+}
+
+/**
+ * Translate a netlink route error into one of our errors where possible
+ */
+func routeError(op, iface, route string, err error) error {
+	switch err {
+	case unix.EEXIST:
+		err = ErrRouteExists
+	case unix.ESRCH:
+		err = ErrRouteNotFound
+	}
+	return netlinkError(op, iface, route, err)
+}
+
+/**
+ * Build a netlink route for an interface from a route in the form "destination [via gateway]"
+ */
+func netlinkRoute(op, iface, route string) (*netlink.Route, error) {
+	dst, gw, err := ParseRoute(route)
+	if err != nil {
+		return nil, &AddrError{Op: op, Iface: iface, Address: route, Err: err}
+	}
+	link, err := netlink.LinkByName(iface)
+	if err != nil {
+		return nil, netlinkError(op, iface, route, err)
+	}
+	return &netlink.Route{LinkIndex: link.Attrs().Index, Dst: dst, Gw: gw}, nil
+}
+
+/**
+ * Add a route in the form "destination [via gateway]" to an interface
+ */
+func AddRoute(iface, route string) error {
+	r, err := netlinkRoute("add route", iface, route)
+	if err != nil {
+		return err
+	}
+	if err := netlink.RouteAdd(r); err != nil {
+		return routeError("add route", iface, route, err)
+	}
+	return nil
+}
+
+/**
+ * Remove a route in the form "destination [via gateway]" from an interface
+ */
+func DeleteRoute(iface, route string) error {
+	r, err := netlinkRoute("delete route", iface, route)
+	if err != nil {
+		return err
+	}
+	if err := netlink.RouteDel(r); err != nil {
+		return routeError("delete route", iface, route, err)
+	}
+	return nil
+}
+
+/**
+ * Return the addresses (in CIDR notation) configured on an interface
+ */
+func ListAddresses(iface string) ([]string, error) {
+	link, err := netlink.LinkByName(iface)
+	if err != nil {
+		return nil, netlinkError("list addresses", iface, "", err)
+	}
+	addrs, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, netlinkError("list addresses", iface, "", err)
+	}
+	var addresses []string
+	for _, addr := range addrs {
+		addresses = append(addresses, addr.IPNet.String())
+	}
+	return addresses, nil
+}
+
+/**
+ * Returns true if the interface is administratively up and has a carrier
+ */
+func InterfaceUp(iface string) (bool, error) {
+	link, err := netlink.LinkByName(iface)
+	if err != nil {
+		return false, netlinkError("get link state", iface, "", err)
+	}
+	attrs := link.Attrs()
+	if attrs.Flags&unix.IFF_UP == 0 {
+		return false, nil
+	}
+	// Virtual interfaces such as dummies do not report an operational state
+	return attrs.OperState == netlink.OperUp || attrs.OperState == netlink.OperUnknown, nil
+}
+
+/**
+ * Send an unsolicited neighbour advertisement for an IPv6 address so our
+ * neighbours update their caches. This is the IPv6 equivalent of a gratuitous ARP.
+ */
+func SendUnsolicitedNA(iface, address string) error {
+	ip, _, err := net.ParseCIDR(address)
+	if err != nil {
+		if ip = net.ParseIP(address); ip == nil {
+			return &AddrError{Op: "advertise", Iface: iface, Address: address, Err: ErrInvalidAddress}
+		}
+	}
+	link, err := net.InterfaceByName(iface)
+	if err != nil {
+		return &AddrError{Op: "advertise", Iface: iface, Address: address, Err: ErrNoSuchDevice}
+	}
+	fd, err := unix.Socket(unix.AF_INET6, unix.SOCK_RAW, unix.IPPROTO_ICMPV6)
+	if err != nil {
+		return netlinkError("advertise", iface, address, err)
+	}
+	defer unix.Close(fd)
+	// Neighbour discovery messages must have a hop limit of 255
+	if err := unix.SetsockoptInt(fd, unix.IPPROTO_IPV6, unix.IPV6_MULTICAST_HOPS, 255); err != nil {
+		return netlinkError("advertise", iface, address, err)
+	}
+	if err := unix.SetsockoptInt(fd, unix.IPPROTO_IPV6, unix.IPV6_MULTICAST_IF, link.Index); err != nil {
+		return netlinkError("advertise", iface, address, err)
+	}
+	// Send from the floating IP itself
+	src := &unix.SockaddrInet6{ZoneId: uint32(link.Index)}
+	copy(src.Addr[:], ip.To16())
+	if err := unix.Bind(fd, src); err != nil {
+		return netlinkError("advertise", iface, address, err)
+	}
+	// All nodes multicast address
+	dst := &unix.SockaddrInet6{ZoneId: uint32(link.Index)}
+	copy(dst.Addr[:], net.IPv6linklocalallnodes)
+	if err := unix.Sendto(fd, neighborAdvertisement(ip, link.HardwareAddr), 0, dst); err != nil {
+		return netlinkError("advertise", iface, address, err)
+	}
+	return nil
+}
+
+/**
+ * Send a gratuitous ARP for an IPv4 address over a raw packet socket so the
+ * switches and routers on the segment learn our MAC address.
+ */
+func SendGARP(iface, address string) error {
+	ip, _, err := net.ParseCIDR(address)
+	if err != nil {
+		if ip = net.ParseIP(address); ip == nil {
+			return &AddrError{Op: "announce", Iface: iface, Address: address, Err: ErrInvalidAddress}
+		}
+	}
+	if ip.To4() == nil {
+		return &AddrError{Op: "announce", Iface: iface, Address: address, Err: ErrInvalidAddress}
+	}
+	link, err := net.InterfaceByName(iface)
+	if err != nil {
+		return &AddrError{Op: "announce", Iface: iface, Address: address, Err: ErrNoSuchDevice}
+	}
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW, int(htons(unix.ETH_P_ARP)))
+	if err != nil {
+		return netlinkError("announce", iface, address, err)
+	}
+	defer unix.Close(fd)
+	dst := &unix.SockaddrLinklayer{
+		Protocol: htons(unix.ETH_P_ARP),
+		Ifindex:  link.Index,
+		Halen:    6,
+	}
+	copy(dst.Addr[:], []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	if err := unix.Sendto(fd, garpFrame(link.HardwareAddr, ip), 0, dst); err != nil {
+		return netlinkError("announce", iface, address, err)
+	}
+	return nil
+}
+
+/**
+ * Convert a short to network byte order
+ */
+func htons(i uint16) uint16 {
+	return (i<<8)&0xff00 | i>>8
+}
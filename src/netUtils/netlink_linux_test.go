@@ -0,0 +1,292 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package netUtils
+
+import (
+	"errors"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+	"os"
+	"runtime"
+	"testing"
+)
+
+/**
+ * Run a test inside a new network namespace. Only the loopback
+ * interface exists in a new namespace so we bring it up and use it.
+ */
+func withTestNamespace(t *testing.T, test func(iface string)) {
+	if os.Getuid() != 0 {
+		t.Skip("network namespace tests must be run as root")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	origin, err := netns.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer origin.Close()
+	ns, err := netns.New()
+	if err != nil {
+		t.Skipf("unable to create a network namespace: %s", err)
+	}
+	defer ns.Close()
+	defer netns.Set(origin)
+	lo, err := netlink.LinkByName("lo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := netlink.LinkSetUp(lo); err != nil {
+		t.Fatal(err)
+	}
+	test("lo")
+}
+
+/**
+ * Create a veth pair in the test namespace for tests that need a real
+ * ethernet interface. Returns the name of one end.
+ */
+func addTestVeth(t *testing.T) string {
+	veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "veth0"}, PeerName: "veth1"}
+	if err := netlink.LinkAdd(veth); err != nil {
+		t.Skipf("unable to create a veth pair: %s", err)
+	}
+	for _, name := range []string{"veth0", "veth1"} {
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := netlink.LinkSetUp(link); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return "veth0"
+}
+
+func TestNetlinkAddresses(t *testing.T) {
+	withTestNamespace(t, func(iface string) {
+		if up, err := InterfaceUp(iface); err != nil || !up {
+			t.Errorf("expected %s to be up, got %t %v", iface, up, err)
+		}
+		if err := AddAddress(iface, "10.10.10.10/24"); err != nil {
+			t.Fatal(err)
+		}
+		if err := AddAddress(iface, "10.10.10.10/24"); !errors.Is(err, ErrAddressExists) {
+			t.Errorf("expected ErrAddressExists, got %v", err)
+		}
+		addresses, err := ListAddresses(iface)
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, address := range addresses {
+			if address == "10.10.10.10/24" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected 10.10.10.10/24 in %v", addresses)
+		}
+		if err := DeleteAddress(iface, "10.10.10.10/24"); err != nil {
+			t.Fatal(err)
+		}
+		if err := DeleteAddress(iface, "10.10.10.10/24"); !errors.Is(err, ErrAddressNotFound) {
+			t.Errorf("expected ErrAddressNotFound, got %v", err)
+		}
+		if err := AddAddress("missing0", "10.10.10.10/24"); !errors.Is(err, ErrNoSuchDevice) {
+			t.Errorf("expected ErrNoSuchDevice, got %v", err)
+		}
+		if err := AddAddress(iface, "nonsense"); !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("expected ErrInvalidAddress, got %v", err)
+		}
+	})
+}
+
+func TestNetlinkIPv6Addresses(t *testing.T) {
+	withTestNamespace(t, func(iface string) {
+		if err := AddAddress(iface, "2001:db8::10/64"); err != nil {
+			t.Fatal(err)
+		}
+		link, _ := netlink.LinkByName(iface)
+		addrs, err := netlink.AddrList(link, netlink.FAMILY_V6)
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, addr := range addrs {
+			if addr.IP.String() != "2001:db8::10" {
+				continue
+			}
+			found = true
+			if addr.Flags&unix.IFA_F_NODAD == 0 {
+				t.Error("expected duplicate address detection to be disabled")
+			}
+		}
+		if !found {
+			t.Fatalf("expected 2001:db8::10 in %v", addrs)
+		}
+		if err := DeleteAddress(iface, "2001:db8::10/64"); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestSendGARP(t *testing.T) {
+	withTestNamespace(t, func(iface string) {
+		if err := SendGARP(iface, "10.10.10.10/24"); err != nil {
+			t.Error(err)
+		}
+		if err := SendGARP(iface, "2001:db8::10/64"); !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("expected ErrInvalidAddress, got %v", err)
+		}
+		if err := SendGARP("missing0", "10.10.10.10/24"); !errors.Is(err, ErrNoSuchDevice) {
+			t.Errorf("expected ErrNoSuchDevice, got %v", err)
+		}
+	})
+}
+
+func TestSendUnsolicitedNA(t *testing.T) {
+	withTestNamespace(t, func(string) {
+		// Loopback cannot carry multicast so advertise on one end of a veth pair
+		iface := addTestVeth(t)
+		// We send from the floating IP so it must be on the interface
+		if err := AddAddress(iface, "2001:db8::10/64"); err != nil {
+			t.Fatal(err)
+		}
+		if err := SendUnsolicitedNA(iface, "2001:db8::10/64"); err != nil {
+			t.Error(err)
+		}
+		if err := SendUnsolicitedNA(iface, "nonsense"); !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("expected ErrInvalidAddress, got %v", err)
+		}
+		if err := SendUnsolicitedNA("missing0", "2001:db8::10/64"); !errors.Is(err, ErrNoSuchDevice) {
+			t.Errorf("expected ErrNoSuchDevice, got %v", err)
+		}
+	})
+}
+
+func TestNetlinkRoutes(t *testing.T) {
+	withTestNamespace(t, func(iface string) {
+		if err := AddAddress(iface, "10.10.10.10/24"); err != nil {
+			t.Fatal(err)
+		}
+		if err := AddRoute(iface, "192.168.50.0/24 via 10.10.10.1"); err != nil {
+			t.Fatal(err)
+		}
+		if err := AddRoute(iface, "192.168.50.0/24 via 10.10.10.1"); !errors.Is(err, ErrRouteExists) {
+			t.Errorf("expected ErrRouteExists, got %v", err)
+		}
+		if err := DeleteRoute(iface, "192.168.50.0/24 via 10.10.10.1"); err != nil {
+			t.Fatal(err)
+		}
+		if err := DeleteRoute(iface, "192.168.50.0/24 via 10.10.10.1"); !errors.Is(err, ErrRouteNotFound) {
+			t.Errorf("expected ErrRouteNotFound, got %v", err)
+		}
+		if err := AddRoute(iface, "192.168.50.0/24 dev eth0"); !errors.Is(err, ErrInvalidRoute) {
+			t.Errorf("expected ErrInvalidRoute, got %v", err)
+		}
+	})
+}
+
+func TestNetlinkVMAC(t *testing.T) {
+	withTestNamespace(t, func(string) {
+		parent := addTestVeth(t)
+		name, err := AddVMAC(parent, 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != "vrrp.7" {
+			t.Errorf("expected vrrp.7, got %s", name)
+		}
+		// Adding it again is a no-op
+		if _, err := AddVMAC(parent, 7); err != nil {
+			t.Errorf("AddVMAC() should succeed when the interface exists: %s", err)
+		}
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := link.(*netlink.Macvlan); !ok {
+			t.Errorf("expected a macvlan interface, got %s", link.Type())
+		}
+		if mac := link.Attrs().HardwareAddr.String(); mac != "00:00:5e:00:01:07" {
+			t.Errorf("expected the VRRP virtual MAC, got %s", mac)
+		}
+		if err := DeleteLink(name); err != nil {
+			t.Fatal(err)
+		}
+		if InterfaceExist(name) {
+			t.Errorf("expected %s to be removed", name)
+		}
+		if _, err := AddVMAC("missing0", 7); !errors.Is(err, ErrNoSuchDevice) {
+			t.Errorf("expected ErrNoSuchDevice, got %v", err)
+		}
+	})
+}
+
+func TestNetlinkVLAN(t *testing.T) {
+	withTestNamespace(t, func(string) {
+		parent := addTestVeth(t)
+		name := VLANName(parent, 10)
+		spec := LinkSpec{Type: LinkVLAN, Parent: parent, VLAN: 10}
+		if err := AddLink(name, spec); errors.Is(err, unix.EOPNOTSUPP) {
+			t.Skip("the kernel does not support VLAN interfaces")
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if err := AddLink(name, spec); err != nil {
+			t.Errorf("AddLink() should succeed when the interface exists: %s", err)
+		}
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if vlan, ok := link.(*netlink.Vlan); !ok || vlan.VlanId != 10 {
+			t.Errorf("expected VLAN 10, got %s %v", link.Type(), link)
+		}
+		if err := DeleteLink(name); err != nil {
+			t.Fatal(err)
+		}
+		if err := DeleteLink(name); !errors.Is(err, ErrNoSuchDevice) {
+			t.Errorf("expected ErrNoSuchDevice, got %v", err)
+		}
+	})
+}
+
+func TestNetlinkMacvlanLink(t *testing.T) {
+	withTestNamespace(t, func(string) {
+		parent := addTestVeth(t)
+		if err := AddLink("mv0", LinkSpec{Type: LinkMacvlan, Parent: parent, Mode: "bridge"}); err != nil {
+			t.Fatal(err)
+		}
+		link, err := netlink.LinkByName("mv0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if macvlan, ok := link.(*netlink.Macvlan); !ok || macvlan.Mode != netlink.MACVLAN_MODE_BRIDGE {
+			t.Errorf("expected a bridge mode macvlan, got %s %v", link.Type(), link)
+		}
+		if err := DeleteLink("mv0"); err != nil {
+			t.Fatal(err)
+		}
+		if err := AddLink("mv0", LinkSpec{Type: LinkMacvlan, Parent: "missing0"}); !errors.Is(err, ErrNoSuchDevice) {
+			t.Errorf("expected ErrNoSuchDevice, got %v", err)
+		}
+	})
+}
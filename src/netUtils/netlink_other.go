@@ -0,0 +1,118 @@
+//go:build !linux
+// +build !linux
+
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package netUtils
+
+import (
+	"net"
+)
+
+/**
+ * Add an address in CIDR notation to an interface
+ */
+func AddAddress(iface, address string) error {
+	return &AddrError{Op: "add", Iface: iface, Address: address, Err: ErrNotSupported}
+}
+
+/**
+ * Remove an address in CIDR notation from an interface
+ */
+func DeleteAddress(iface, address string) error {
+	return &AddrError{Op: "delete", Iface: iface, Address: address, Err: ErrNotSupported}
+}
+
+/**
+ * Create an interface from its spec
+ */
+func AddLink(name string, spec LinkSpec) error {
+	return &AddrError{Op: "add link", Iface: name, Err: ErrNotSupported}
+}
+
+/**
+ * Remove an interface created by AddLink
+ */
+func DeleteLink(name string) error {
+	return &AddrError{Op: "delete link", Iface: name, Err: ErrNotSupported}
+}
+
+/**
+ * Create a virtual MAC interface on the parent for the virtual router ID
+ */
+func AddVMAC(parent string, vrid int) (string, error) {
+	return "", &AddrError{Op: "add virtual mac", Iface: parent, Address: VMACName(vrid), Err: ErrNotSupported}
+}
+
+/**
+ * Add a route in the form "destination [via gateway]" to an interface
+ */
+func AddRoute(iface, route string) error {
+	return &AddrError{Op: "add route", Iface: iface, Address: route, Err: ErrNotSupported}
+}
+
+/**
+ * Remove a route in the form "destination [via gateway]" from an interface
+ */
+func DeleteRoute(iface, route string) error {
+	return &AddrError{Op: "delete route", Iface: iface, Address: route, Err: ErrNotSupported}
+}
+
+/**
+ * Return the addresses (in CIDR notation) configured on an interface
+ */
+func ListAddresses(iface string) ([]string, error) {
+	link, err := net.InterfaceByName(iface)
+	if err != nil {
+		return nil, &AddrError{Op: "list addresses", Iface: iface, Err: ErrNoSuchDevice}
+	}
+	addrs, err := link.Addrs()
+	if err != nil {
+		return nil, &AddrError{Op: "list addresses", Iface: iface, Err: err}
+	}
+	var addresses []string
+	for _, addr := range addrs {
+		addresses = append(addresses, addr.String())
+	}
+	return addresses, nil
+}
+
+/**
+ * Returns true if the interface is up
+ */
+func InterfaceUp(iface string) (bool, error) {
+	link, err := net.InterfaceByName(iface)
+	if err != nil {
+		return false, &AddrError{Op: "get link state", Iface: iface, Err: ErrNoSuchDevice}
+	}
+	return link.Flags&net.FlagUp != 0, nil
+}
+
+/**
+ * Send an unsolicited neighbour advertisement for an IPv6 address
+ */
+func SendUnsolicitedNA(iface, address string) error {
+	return &AddrError{Op: "advertise", Iface: iface, Address: address, Err: ErrNotSupported}
+}
+
+/**
+ * Send a gratuitous ARP for an IPv4 address
+ */
+func SendGARP(iface, address string) error {
+	return &AddrError{Op: "announce", Iface: iface, Address: address, Err: ErrNotSupported}
+}
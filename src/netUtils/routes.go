@@ -0,0 +1,47 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package netUtils
+
+import (
+	"net"
+	"strings"
+)
+
+/**
+ * Parse a route in the form "destination [via gateway]".
+ * The destination must be in CIDR notation. The gateway is nil for routes on the link.
+ */
+func ParseRoute(route string) (*net.IPNet, net.IP, error) {
+	fields := strings.Fields(route)
+	if len(fields) != 1 && (len(fields) != 3 || fields[1] != "via") {
+		return nil, nil, ErrInvalidRoute
+	}
+	_, dst, err := net.ParseCIDR(fields[0])
+	if err != nil {
+		return nil, nil, ErrInvalidRoute
+	}
+	if len(fields) == 1 {
+		return dst, nil, nil
+	}
+	gw := net.ParseIP(fields[2])
+	// The gateway must be the same address family as the destination
+	if gw == nil || (gw.To4() == nil) != (dst.IP.To4() == nil) {
+		return nil, nil, ErrInvalidRoute
+	}
+	return dst, gw, nil
+}
@@ -0,0 +1,41 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package netUtils
+
+import (
+	"testing"
+)
+
+func TestParseRoute(t *testing.T) {
+	dst, gw, err := ParseRoute("192.168.50.0/24 via 10.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dst.String() != "192.168.50.0/24" || gw.String() != "10.0.0.1" {
+		t.Errorf("unexpected route %s via %s", dst, gw)
+	}
+	if dst, gw, err = ParseRoute("2001:db8:50::/48"); err != nil || gw != nil || dst.String() != "2001:db8:50::/48" {
+		t.Errorf("unexpected link route %s via %s: %v", dst, gw, err)
+	}
+	invalid := []string{"", "192.168.50.0/24 via", "192.168.50.0/24 dev eth0", "192.168.50.1 via 10.0.0.1", "192.168.50.0/24 via 2001:db8::1"}
+	for _, route := range invalid {
+		if _, _, err := ParseRoute(route); err != ErrInvalidRoute {
+			t.Errorf("expected %q to be invalid", route)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package netUtils
+
+import (
+	"net"
+	"strconv"
+)
+
+/**
+ * Returns the VRRP virtual MAC address for a virtual router ID (00:00:5e:00:01:VRID)
+ */
+func VirtualMAC(vrid int) net.HardwareAddr {
+	return net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x01, byte(vrid)}
+}
+
+/**
+ * Returns the name of the macvlan interface used for a virtual router ID
+ */
+func VMACName(vrid int) string {
+	return "vrrp." + strconv.Itoa(vrid)
+}
@@ -0,0 +1,31 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package netUtils
+
+import (
+	"testing"
+)
+
+func TestVirtualMAC(t *testing.T) {
+	if mac := VirtualMAC(51).String(); mac != "00:00:5e:00:01:33" {
+		t.Errorf("unexpected virtual MAC %s", mac)
+	}
+	if name := VMACName(51); name != "vrrp.51" {
+		t.Errorf("unexpected interface name %s", name)
+	}
+}
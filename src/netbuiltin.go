@@ -0,0 +1,113 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"errors"
+	"github.com/Syleron/PulseHA/src/netUtils"
+	log "github.com/Sirupsen/logrus"
+)
+
+/**
+Built-in networking plugin which manages addresses through netlink.
+Used when no networking plugin has been installed.
+*/
+type builtinNet struct{}
+
+func (n *builtinNet) Name() string {
+	return "netlink"
+}
+
+func (n *builtinNet) Version() float64 {
+	return 1.0
+}
+
+/**
+Bring up the ips on an interface. IPs that are already up are skipped.
+*/
+func (n *builtinNet) BringUpIPs(iface string, ips []string) error {
+	for _, ip := range ips {
+		err := netUtils.AddAddress(iface, ip)
+		if errors.Is(err, netUtils.ErrAddressExists) {
+			log.Debug("builtinNet:BringUpIPs() " + ip + " is already up on " + iface)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+Add the routes to an interface. Routes that already exist are skipped.
+*/
+func (n *builtinNet) AddRoutes(iface string, routes []string) error {
+	for _, route := range routes {
+		err := netUtils.AddRoute(iface, route)
+		if errors.Is(err, netUtils.ErrRouteExists) {
+			log.Debug("builtinNet:AddRoutes() " + route + " already exists on " + iface)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+Remove the routes from an interface. Routes that do not exist are skipped.
+*/
+func (n *builtinNet) DeleteRoutes(iface string, routes []string) error {
+	for _, route := range routes {
+		err := netUtils.DeleteRoute(iface, route)
+		if errors.Is(err, netUtils.ErrRouteNotFound) {
+			log.Debug("builtinNet:DeleteRoutes() " + route + " does not exist on " + iface)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+Bring down the ips on an interface. IPs that are already down are skipped.
+*/
+func (n *builtinNet) BringDownIPs(iface string, ips []string) error {
+	for _, ip := range ips {
+		err := netUtils.DeleteAddress(iface, ip)
+		if errors.Is(err, netUtils.ErrAddressNotFound) {
+			log.Debug("builtinNet:BringDownIPs() " + ip + " is already down on " + iface)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+Returns the addresses on an interface
+*/
+func (n *builtinNet) ListIPs(iface string) ([]string, error) {
+	return netUtils.ListAddresses(iface)
+}
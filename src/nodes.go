@@ -20,6 +20,7 @@ package main
 import (
 	"errors"
 	log "github.com/Sirupsen/logrus"
+	"sort"
 )
 
 /**
@@ -118,3 +119,29 @@ func NodeInterfaceGroupExists(node, iface, group string) (bool, int) {
 	}
 	return false, -1
 }
+
+/**
+ * Returns the configured node hostnames ordered by priority (highest first).
+ * Nodes with the same priority are ordered by hostname so every node agrees on the order.
+ */
+func NodesByPriority() []string {
+	config := gconf.GetConfig()
+	var hostnames []string
+	for hostname := range config.Nodes {
+		hostnames = append(hostnames, hostname)
+	}
+	sort.Slice(hostnames, func(i, j int) bool {
+		return nodeOutranks(config.Nodes, hostnames[i], hostnames[j])
+	})
+	return hostnames
+}
+
+/**
+ * Returns true if node a should be preferred over node b when electing an active node.
+ */
+func nodeOutranks(nodes map[string]Node, a, b string) bool {
+	if nodes[a].Priority != nodes[b].Priority {
+		return nodes[a].Priority > nodes[b].Priority
+	}
+	return a < b
+}
@@ -16,3 +16,23 @@
    along with this program.  If not, see <http://www.gnu.org/licenses/>.
 */
 package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNodesByPriority(t *testing.T) {
+	gconf.SetConfig(Config{
+		Nodes: map[string]Node{
+			"node-c": {Priority: 10},
+			"node-a": {Priority: 5},
+			"node-b": {Priority: 10},
+			"node-d": {},
+		},
+	})
+	want := []string{"node-b", "node-c", "node-a", "node-d"}
+	if got := NodesByPriority(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NodesByPriority() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,288 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"errors"
+	p "github.com/Syleron/PulseHA/proto"
+	log "github.com/Sirupsen/logrus"
+	"sort"
+)
+
+/**
+ * Returns true if each group can be active on a different node
+ */
+func activeActive() bool {
+	return gconf.GetConfig().Pulse.ActiveActive
+}
+
+/**
+ * Work out the owner of every group.
+ * Groups keep their current owner while it is available. Any other group is given
+ * to the available candidate that owns the fewest groups, preferring higher priority nodes.
+ * candidates are the nodes each group is assigned to and order is the node priority order.
+ */
+func assignGroupOwners(current map[string]string, candidates map[string][]string, available func(hostname string) bool, order []string) map[string]string {
+	owners := make(map[string]string)
+	owned := make(map[string]int)
+	var groups []string
+	for group := range candidates {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	// Keep existing owners where we can
+	for _, group := range groups {
+		owner := current[group]
+		if owner != "" && available(owner) && contains(candidates[group], owner) {
+			owners[group] = owner
+			owned[owner]++
+		}
+	}
+	// Spread the rest
+	for _, group := range groups {
+		if _, ok := owners[group]; ok {
+			continue
+		}
+		best := ""
+		for _, hostname := range order {
+			if !available(hostname) || !contains(candidates[group], hostname) {
+				continue
+			}
+			if best == "" || owned[hostname] < owned[best] {
+				best = hostname
+			}
+		}
+		if best != "" {
+			owners[group] = best
+			owned[best]++
+		}
+	}
+	return owners
+}
+
+/**
+ * Returns true if the slice contains the value
+ */
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Returns true if a member can own groups from the point of view of the active member
+ */
+func (m *Memberlist) canOwnGroups(hostname string) bool {
+	member := m.GetMemberByHostname(hostname)
+	if member == nil || nodeInMaintenance(hostname) || member.getLinkDown() || member.getUnhealthy() {
+		return false
+	}
+	status := member.getStatus()
+	return status == p.MemberStatus_ACTIVE || status == p.MemberStatus_PASSIVE
+}
+
+/**
+ * Active function - Work out the owner of each group and apply it locally.
+ */
+func (m *Memberlist) assignGroups() {
+	config := gconf.GetConfig()
+	candidates := make(map[string][]string)
+	for group := range config.Groups {
+		nodes, _ := getGroupNodes(group)
+		candidates[group] = nodes
+	}
+	current := m.getGroupOwners()
+	owners := assignGroupOwners(current, candidates, m.canOwnGroups, NodesByPriority())
+	for group, owner := range owners {
+		if current[group] != owner {
+			log.Infof("Group %s is now owned by %s", group, owner)
+		}
+	}
+	m.setGroupOwners(owners)
+	m.applyGroupOwners()
+}
+
+/**
+ * Return a copy of the group owners
+ */
+func (m *Memberlist) getGroupOwners() map[string]string {
+	m.Lock()
+	defer m.Unlock()
+	owners := make(map[string]string)
+	for group, owner := range m.groupOwners {
+		owners[group] = owner
+	}
+	return owners
+}
+
+/**
+ * Replace the group owners
+ */
+func (m *Memberlist) setGroupOwners(owners map[string]string) {
+	m.Lock()
+	defer m.Unlock()
+	m.groupOwners = owners
+}
+
+/**
+ * Return the owner of a group. Without active/active the active member
+ * owns every group assigned to it.
+ */
+func (m *Memberlist) getGroupOwner(group string) string {
+	if activeActive() {
+		return m.getGroupOwners()[group]
+	}
+	activeHostname, _ := m.getActiveMember()
+	if nodes, _ := getGroupNodes(group); contains(nodes, activeHostname) {
+		return activeHostname
+	}
+	return ""
+}
+
+/**
+ * Returns true if the local node should be holding the group's floating IPs
+ */
+func (m *Memberlist) ownsGroup(group string) bool {
+	if activeActive() {
+		return m.getGroupOwners()[group] == gconf.getLocalNode()
+	}
+	localMember, err := m.getLocalMember()
+	return err == nil && localMember.getStatus() == p.MemberStatus_ACTIVE
+}
+
+/**
+ * Convert the group owners into their proto message form
+ */
+func (m *Memberlist) groupOwnersProto() []*p.GroupOwner {
+	var owners []*p.GroupOwner
+	config := gconf.GetConfig()
+	var groups []string
+	for group := range config.Groups {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		owners = append(owners, &p.GroupOwner{
+			Group: group,
+			Owner: m.getGroupOwner(group),
+		})
+	}
+	return owners
+}
+
+/**
+ * Update the group owners from the proto message sent by the active member
+ */
+func (m *Memberlist) updateGroupOwners(owners []*p.GroupOwner) {
+	newOwners := make(map[string]string)
+	for _, owner := range owners {
+		if owner.Owner != "" {
+			newOwners[owner.Group] = owner.Owner
+		}
+	}
+	m.setGroupOwners(newOwners)
+}
+
+/**
+ * Bring up the groups we own and bring down the ones we don't.
+ * Only groups whose state needs to change are touched.
+ */
+func (m *Memberlist) applyGroupOwners() {
+	config := gconf.GetConfig()
+	lister := ipLister()
+	for iface, groups := range config.LocalNode().IPGroups {
+		for _, group := range groups {
+			ips := config.Groups[group]
+			if len(ips) == 0 {
+				continue
+			}
+			owns := m.ownsGroup(group)
+			var up, down bool
+			if lister == nil {
+				// Go by what we have asked the plugin to do when it cannot tell us what it holds
+				held := heldGroups.held(iface, group)
+				up, down = owns && !held, !owns && held
+			} else {
+				settings := config.GetGroupSettings(group)
+				actual, err := lister.ListIPs(groupIface(iface, settings))
+				// Virtual MAC and managed interfaces only exist while we hold their groups
+				if err != nil && settings.VRID == 0 && !linkManaged(iface) {
+					log.Warnf("Unable to get the addresses for interface %s: %s", iface, err.Error())
+					continue
+				}
+				missing, _ := addressDrift(ips, nil, actual)
+				_, stray := addressDrift(nil, ips, actual)
+				up, down = owns && len(missing) > 0, !owns && len(stray) > 0
+			}
+			if up {
+				log.Info("Bringing up group " + group + " as we are now its owner")
+				makeGroupActive(iface, group)
+			} else if down {
+				log.Info("Bringing down group " + group + " as we are no longer its owner")
+				makeGroupPassive(iface, group)
+			}
+		}
+	}
+}
+
+/**
+ * Move a group to a member. Only available in active/active mode.
+ * The request is forwarded to the active member as it decides the group owners.
+ */
+func (m *Memberlist) PromoteGroup(group, hostname string) error {
+	if !activeActive() {
+		return errors.New("groups can only be promoted individually when active_active is enabled")
+	}
+	if !GroupExist(group) {
+		return errors.New("group does not exist")
+	}
+	if nodes, _ := getGroupNodes(group); !contains(nodes, hostname) {
+		return errors.New("the group is not assigned to " + hostname)
+	}
+	activeHostname, activeMember := m.getActiveMember()
+	if activeMember == nil {
+		return errors.New("unable to promote group as there is no active member")
+	}
+	if activeHostname != gconf.getLocalNode() {
+		activeMember.Connect()
+		r, err := activeMember.Send(SendPromote, &p.PulsePromote{
+			Member: hostname,
+			Group:  group,
+			Term:   term.Get(),
+		})
+		if err != nil {
+			return err
+		}
+		if promote := r.(*p.PulsePromote); !promote.Success {
+			return errors.New(promote.Message)
+		}
+		return nil
+	}
+	if !m.canOwnGroups(hostname) {
+		return errors.New("unable to promote group as " + hostname + " is unavailable")
+	}
+	log.Infof("Promoting %s as the owner of group %s", hostname, group)
+	owners := m.getGroupOwners()
+	owners[group] = hostname
+	m.setGroupOwners(owners)
+	m.applyGroupOwners()
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAssignGroupOwners(t *testing.T) {
+	candidates := map[string][]string{
+		"group1": {"node1", "node2"},
+		"group2": {"node1", "node2"},
+		"group3": {"node1", "node2", "node3"},
+		"group4": {"node3"},
+	}
+	order := []string{"node1", "node2", "node3"}
+	available := func(hostname string) bool {
+		return hostname != "node3"
+	}
+	// Groups are spread across the available nodes
+	owners := assignGroupOwners(nil, candidates, available, order)
+	expected := map[string]string{
+		"group1": "node1",
+		"group2": "node2",
+		"group3": "node1",
+	}
+	if !reflect.DeepEqual(owners, expected) {
+		t.Errorf("expected %v, got %v", expected, owners)
+	}
+	// Existing owners are kept while they are available
+	current := map[string]string{"group1": "node2", "group2": "node2", "group4": "node3"}
+	owners = assignGroupOwners(current, candidates, available, order)
+	expected = map[string]string{
+		"group1": "node2",
+		"group2": "node2",
+		"group3": "node1",
+	}
+	if !reflect.DeepEqual(owners, expected) {
+		t.Errorf("expected %v, got %v", expected, owners)
+	}
+}
@@ -29,6 +29,8 @@ import (
 
 /**
 Health Check plugin type
+Send performs the check and returns whether it passed and whether
+the result is conclusive (false if the check could not be performed).
  */
 type PluginHC interface {
 	Name() string
@@ -46,6 +48,35 @@ type PluginNet interface {
 	BringDownIPs(iface string, ips []string) error
 }
 
+/**
+Optional networking plugin type for plugins that can also manage routes.
+Routes are in the form "destination [via gateway]".
+ */
+type PluginNetRoutes interface {
+	AddRoutes(iface string, routes []string) error
+	DeleteRoutes(iface string, routes []string) error
+}
+
+/**
+Optional networking plugin type for plugins that can report the IPs they hold on
+an interface. Without it PulseHA cannot tell which IPs are up so it never
+reconciles them and only tracks the groups it has brought up itself.
+ */
+type PluginNetAddresses interface {
+	ListIPs(iface string) ([]string, error)
+}
+
+/**
+Fencing plugin type
+Fence must make sure the specified node no longer holds any floating IPs,
+returning an error if that could not be guaranteed.
+ */
+type PluginFence interface {
+	Name() string
+	Version() float64
+	Fence(node string) error
+}
+
 /**
 Plugins struct
  */
@@ -68,13 +99,32 @@ type pluginType int
 const (
 	PluginHealthCheck pluginType = 1 + iota
 	PluginNetworking
+	PluginFencing
 )
 
 var pluginTypeNames = []string{
 	"PluginHC",
 	"PluginNet",
+	"PluginFence",
 }
 
+/**
+Health check plugin failover policies
+ */
+const (
+	HCPolicyAll      = "all"
+	HCPolicyAny      = "any"
+	HCPolicyMajority = "majority"
+)
+
+/**
+Fencing failure policies
+ */
+const (
+	FencePolicyAbort    = "abort"
+	FencePolicyContinue = "continue"
+)
+
 func (p pluginType) String() string {
 	return pluginTypeNames[p-1]
 }
@@ -112,6 +162,7 @@ func (p *Plugins) Setup() {
 	}
 	p.Load(PluginHealthCheck, plugins)
 	p.Load(PluginNetworking, plugins)
+	p.Load(PluginFencing, plugins)
 	p.validate()
 	if len(p.modules) > 0 {
 		var pluginNames string = ""
@@ -128,7 +179,14 @@ func (p *Plugins) Setup() {
 func (p *Plugins) validate() {
 	// make sure we have a networking plugin
 	if p.getNetworkingPlugin() == nil {
-		log.Fatal("No networking plugin loaded. Please install a networking plugin in order to use PulseHA")
+		log.Info("No networking plugin installed. Using the built-in netlink networking")
+		builtin := &builtinNet{}
+		p.modules = append(p.modules, &Plugin{
+			Name:    builtin.Name(),
+			Version: builtin.Version(),
+			Type:    PluginNetworking,
+			Plugin:  builtin,
+		})
 	}
 }
 
@@ -149,7 +207,9 @@ func (p *Plugins) Load(pluginType pluginType, pluginList []*plugin.Plugin) {
 			// Create a new instance of plugins
 			newPlugin := &Plugin{
 				Name: e.Name(),
+				Version: e.Version(),
 				Type: pluginType,
+				Plugin: e,
 			}
 			// Add to the list of plugins
 			p.modules = append(p.modules, newPlugin)
@@ -177,6 +237,29 @@ func (p *Plugins) Load(pluginType pluginType, pluginList []*plugin.Plugin) {
 			}
 			// Add to the list of plugins
 			p.modules = append(p.modules, newPlugin)
+		case PluginFencing:
+			// Only one fencing plugin can be loaded at one time.
+			if p.getFencingPlugin() != nil {
+				continue
+			}
+			symEvt, err := plugin.Lookup(pluginType.String())
+			if err != nil {
+				log.Debugf("Plugin does not match pluginType symbol: %v", err)
+				continue
+			}
+			e, ok := symEvt.(PluginFence)
+			if !ok {
+				continue
+			}
+			// Create a new instance of plugins
+			newPlugin := &Plugin{
+				Name: e.Name(),
+				Version: e.Version(),
+				Type: pluginType,
+				Plugin: e,
+			}
+			// Add to the list of plugins
+			p.modules = append(p.modules, newPlugin)
 		}
 	}
 }
@@ -194,6 +277,51 @@ func (p *Plugins) getHealthCheckPlugins() []*Plugin {
 	return modules
 }
 
+/**
+Run every loaded health check plugin and determine, based on the
+configured policy, whether the active member should be considered dead.
+Note: With no conclusive results we have nothing to go on so we assume the worst.
+ */
+func (p *Plugins) activeFailed() bool {
+	failed, total := p.runHealthChecks()
+	return hcPolicyFailed(gconf.Pulse.HCPolicy, failed, total)
+}
+
+/**
+Run every loaded health check plugin returning how many failed out of those that were conclusive
+ */
+func (p *Plugins) runHealthChecks() (failed, total int) {
+	for _, plgin := range p.getHealthCheckPlugins() {
+		success, conclusive := plgin.Plugin.(PluginHC).Send()
+		log.Debugf("Plugins:runHealthChecks() %s returned success: %t conclusive: %t", plgin.Name, success, conclusive)
+		if !conclusive {
+			continue
+		}
+		total++
+		if !success {
+			failed++
+		}
+	}
+	return failed, total
+}
+
+/**
+Determine whether the number of failed checks satisfies a failover policy
+ */
+func hcPolicyFailed(policy string, failed, total int) bool {
+	if total == 0 {
+		return true
+	}
+	switch policy {
+	case HCPolicyAny:
+		return failed > 0
+	case HCPolicyMajority:
+		return failed > total/2
+	default:
+		return failed == total
+	}
+}
+
 /**
 Returns a single networking plugin (as you should only ever have one loaded)
  */
@@ -205,3 +333,34 @@ func (p *Plugins) getNetworkingPlugin() *Plugin {
 	}
 	return nil
 }
+
+/**
+Returns the loaded fencing plugin or nil if there isn't one
+ */
+func (p *Plugins) getFencingPlugin() *Plugin {
+	for _, plgin := range p.modules {
+		if plgin.Type == PluginFencing {
+			return plgin
+		}
+	}
+	return nil
+}
+
+/**
+Fence a node using the loaded fencing plugin.
+Returns true if it is safe to continue with the failover.
+ */
+func (p *Plugins) fence(node string) bool {
+	plgin := p.getFencingPlugin()
+	if plgin == nil || node == "" {
+		return true
+	}
+	log.Infof("Fencing %s using %s", node, plgin.Name)
+	err := plgin.Plugin.(PluginFence).Fence(node)
+	if err == nil {
+		log.Infof("Successfully fenced %s", node)
+		return true
+	}
+	log.Errorf("Failed to fence %s: %s", node, err.Error())
+	return gconf.Pulse.FencePolicy == FencePolicyContinue
+}
@@ -16,3 +16,39 @@
    along with this program.  If not, see <http://www.gnu.org/licenses/>.
 */
 package main
+
+import "testing"
+
+func TestHCPolicyFailed(t *testing.T) {
+	tests := []struct {
+		policy        string
+		failed, total int
+		want          bool
+	}{
+		{HCPolicyAll, 0, 0, true},
+		{HCPolicyAll, 2, 3, false},
+		{HCPolicyAll, 3, 3, true},
+		{HCPolicyAny, 0, 3, false},
+		{HCPolicyAny, 1, 3, true},
+		{HCPolicyMajority, 1, 3, false},
+		{HCPolicyMajority, 2, 3, true},
+		{HCPolicyMajority, 1, 2, false},
+	}
+	for _, tt := range tests {
+		if got := hcPolicyFailed(tt.policy, tt.failed, tt.total); got != tt.want {
+			t.Errorf("hcPolicyFailed(%s, %d, %d) = %t, want %t", tt.policy, tt.failed, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestBuiltinNetworkingDefault(t *testing.T) {
+	p := &Plugins{}
+	p.validate()
+	plugin := p.getNetworkingPlugin()
+	if plugin == nil {
+		t.Fatal("expected the built-in networking plugin to be loaded")
+	}
+	if _, ok := plugin.Plugin.(PluginNet); !ok || plugin.Name != "netlink" {
+		t.Errorf("unexpected networking plugin %s", plugin.Name)
+	}
+}
@@ -0,0 +1,259 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"github.com/Syleron/PulseHA/src/netUtils"
+	log "github.com/Sirupsen/logrus"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+/**
+ * The number of drift events we keep to report in the status
+ */
+const maxDriftEvents = 10
+
+/**
+ * A record of the most recent drift events
+ */
+type driftLog struct {
+	sync.Mutex
+	events []string
+}
+
+var driftEvents driftLog
+
+/**
+ * Record a drift event keeping only the most recent events
+ */
+func (d *driftLog) record(event string) {
+	d.Lock()
+	defer d.Unlock()
+	d.events = append(d.events, time.Now().Format(time.RFC1123)+" "+event)
+	if len(d.events) > maxDriftEvents {
+		d.events = d.events[len(d.events)-maxDriftEvents:]
+	}
+}
+
+/**
+ * Return a copy of the recorded drift events
+ */
+func (d *driftLog) get() []string {
+	d.Lock()
+	defer d.Unlock()
+	return append([]string{}, d.events...)
+}
+
+/**
+ * The difference between the desired and actual floating IPs on an interface
+ */
+type ifaceDrift struct {
+	Iface   string
+	Missing []string
+	Stray   []string
+	// Set when the interface is a virtual MAC interface
+	Parent string
+	VRID   int
+}
+
+/**
+ * Compare the desired floating IPs with the addresses actually on an interface.
+ * Missing are desired IPs that are not configured. Stray are known floating IPs
+ * that are configured but not desired. Addresses are compared by IP only.
+ */
+func addressDrift(desired, floating, actual []string) (missing, stray []string) {
+	have := make(map[string]bool)
+	for _, addr := range actual {
+		have[addressIP(addr)] = true
+	}
+	want := make(map[string]bool)
+	for _, addr := range desired {
+		want[addressIP(addr)] = true
+		if !have[addressIP(addr)] {
+			missing = append(missing, addr)
+		}
+	}
+	for _, addr := range floating {
+		if have[addressIP(addr)] && !want[addressIP(addr)] {
+			stray = append(stray, addr)
+			// Only report each floating IP once
+			want[addressIP(addr)] = true
+		}
+	}
+	return missing, stray
+}
+
+/**
+ * Return the IP portion of an address which may be in CIDR notation
+ */
+func addressIP(addr string) string {
+	if ip, _, err := net.ParseCIDR(addr); err == nil {
+		return ip.String()
+	}
+	return addr
+}
+
+/**
+ * Work out the floating IP drift on each interface assigned to the local node
+ */
+func localDrift(owns func(group string) bool) []ifaceDrift {
+	// We can only work out drift if the networking plugin tells us what it holds
+	lister := ipLister()
+	if lister == nil {
+		return nil
+	}
+	config := gconf.GetConfig()
+	node, ok := config.Nodes[gconf.getLocalNode()]
+	if !ok {
+		return nil
+	}
+	// Every floating IP we know about
+	var floating []string
+	for _, ips := range config.Groups {
+		floating = append(floating, ips...)
+	}
+	// Work out what should be on each interface including virtual MAC interfaces
+	desired := make(map[string]*ifaceDrift)
+	for iface, groups := range node.IPGroups {
+		if _, ok := desired[iface]; !ok {
+			desired[iface] = &ifaceDrift{Iface: iface}
+		}
+		for _, group := range groups {
+			settings := config.GetGroupSettings(group)
+			groupIface := groupIface(iface, settings)
+			if _, ok := desired[groupIface]; !ok {
+				desired[groupIface] = &ifaceDrift{Iface: groupIface, Parent: iface, VRID: settings.VRID}
+			}
+			if owns(group) {
+				desired[groupIface].Missing = append(desired[groupIface].Missing, config.Groups[group]...)
+			}
+		}
+	}
+	var drift []ifaceDrift
+	for iface, want := range desired {
+		actual, err := lister.ListIPs(iface)
+		// Virtual MAC and managed interfaces only exist while we hold their groups
+		if err != nil && want.VRID == 0 && !linkManaged(iface) {
+			log.Warnf("Unable to get the addresses for interface %s: %s", iface, err.Error())
+			continue
+		}
+		missing, stray := addressDrift(want.Missing, floating, actual)
+		if len(missing) > 0 || len(stray) > 0 {
+			drift = append(drift, ifaceDrift{Iface: iface, Missing: missing, Stray: stray, Parent: want.Parent, VRID: want.VRID})
+		}
+	}
+	return drift
+}
+
+/**
+ * Bring the local interfaces in line with the desired floating IP state
+ */
+func applyDrift(drift []ifaceDrift) {
+	for _, d := range drift {
+		if len(d.Missing) > 0 {
+			driftEvents.record("missing " + strings.Join(d.Missing, ", ") + " on " + d.Iface)
+			log.Warnf("Floating IPs %s are missing from interface %s. Bringing them up", strings.Join(d.Missing, ", "), d.Iface)
+			if err := ensureLink(d.Iface); err != nil {
+				log.Errorf("Unable to create interface %s: %s", d.Iface, err.Error())
+				continue
+			}
+			if d.VRID > 0 {
+				if err := ensureLink(d.Parent); err != nil {
+					log.Errorf("Unable to create interface %s: %s", d.Parent, err.Error())
+					continue
+				}
+				if _, err := netUtils.AddVMAC(d.Parent, d.VRID); err != nil {
+					log.Errorf("Unable to create virtual MAC interface %s: %s", d.Iface, err.Error())
+					continue
+				}
+			}
+			bringUpIPs(d.Iface, d.Missing)
+		}
+		if len(d.Stray) > 0 {
+			driftEvents.record("unexpected " + strings.Join(d.Stray, ", ") + " on " + d.Iface)
+			log.Warnf("Unexpected floating IPs %s found on interface %s. Bringing them down", strings.Join(d.Stray, ", "), d.Iface)
+			bringDownIPs(d.Iface, d.Stray)
+		}
+	}
+}
+
+/**
+ * Reconcile the floating IPs on the local interfaces with the groups we own.
+ * The owner re-asserts its IPs and everyone else removes any they should not hold.
+ */
+func reconcileLocal(owns func(group string) bool) []ifaceDrift {
+	log.Debug("Reconcile:reconcileLocal() Reconciling local floating IPs")
+	drift := localDrift(owns)
+	applyDrift(drift)
+	return drift
+}
+
+/**
+ * Only keep the drift that was also seen on the previous pass. This stops us
+ * fighting a role change that is bringing IPs up or down at the same time.
+ * Returns the confirmed drift and the drift seen on this pass.
+ */
+func confirmDrift(previous map[string]bool, drift []ifaceDrift) ([]ifaceDrift, map[string]bool) {
+	seen := make(map[string]bool)
+	var confirmed []ifaceDrift
+	for _, d := range drift {
+		c := ifaceDrift{Iface: d.Iface, Parent: d.Parent, VRID: d.VRID}
+		for _, addr := range d.Missing {
+			key := "missing " + d.Iface + " " + addr
+			seen[key] = true
+			if previous[key] {
+				c.Missing = append(c.Missing, addr)
+			}
+		}
+		for _, addr := range d.Stray {
+			key := "stray " + d.Iface + " " + addr
+			seen[key] = true
+			if previous[key] {
+				c.Stray = append(c.Stray, addr)
+			}
+		}
+		if len(c.Missing) > 0 || len(c.Stray) > 0 {
+			confirmed = append(confirmed, c)
+		}
+	}
+	return confirmed, seen
+}
+
+/**
+ * Periodically compare the floating IPs on our interfaces with our role and fix any drift.
+ */
+func (m *Memberlist) reconcile() bool {
+	if _, err := m.getLocalMember(); err != nil {
+		log.Debug("Memberlist:reconcile() Reconciler has stopped as it seems we are no longer in a cluster")
+		return true
+	}
+	if ipLister() == nil {
+		log.Debug("Memberlist:reconcile() Skipping as the networking plugin cannot report its IPs")
+		return false
+	}
+	drift := localDrift(m.ownsGroup)
+	m.Lock()
+	confirmed, seen := confirmDrift(m.driftSeen, drift)
+	m.driftSeen = seen
+	m.Unlock()
+	applyDrift(confirmed)
+	return false
+}
@@ -0,0 +1,109 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"github.com/Syleron/PulseHA/proto"
+	"github.com/Syleron/PulseHA/src/utils"
+	"reflect"
+	"testing"
+)
+
+func TestAddressDrift(t *testing.T) {
+	floating := []string{"10.0.0.10/24", "10.0.0.11/24", "10.0.0.12/24"}
+	actual := []string{"10.0.0.1/24", "10.0.0.10/24", "10.0.0.12/24"}
+	// Active should hold 10 and 11 but also holds 12
+	missing, stray := addressDrift([]string{"10.0.0.10/24", "10.0.0.11/24"}, floating, actual)
+	if !reflect.DeepEqual(missing, []string{"10.0.0.11/24"}) {
+		t.Errorf("unexpected missing addresses %v", missing)
+	}
+	if !reflect.DeepEqual(stray, []string{"10.0.0.12/24"}) {
+		t.Errorf("unexpected stray addresses %v", stray)
+	}
+	// A passive should hold no floating IPs but never touch the node address
+	missing, stray = addressDrift(nil, floating, actual)
+	if len(missing) != 0 {
+		t.Errorf("unexpected missing addresses %v", missing)
+	}
+	if !reflect.DeepEqual(stray, []string{"10.0.0.10/24", "10.0.0.12/24"}) {
+		t.Errorf("unexpected stray addresses %v", stray)
+	}
+}
+
+func TestConfirmDrift(t *testing.T) {
+	drift := []ifaceDrift{{Iface: "eth0", Missing: []string{"10.0.0.10/24"}}}
+	confirmed, seen := confirmDrift(nil, drift)
+	if len(confirmed) != 0 {
+		t.Fatalf("drift should not be confirmed on the first pass, got %v", confirmed)
+	}
+	drift = []ifaceDrift{{Iface: "eth0", Missing: []string{"10.0.0.10/24"}, Stray: []string{"10.0.0.11/24"}}}
+	confirmed, _ = confirmDrift(seen, drift)
+	expected := []ifaceDrift{{Iface: "eth0", Missing: []string{"10.0.0.10/24"}}}
+	if !reflect.DeepEqual(confirmed, expected) {
+		t.Errorf("expected %v, got %v", expected, confirmed)
+	}
+}
+
+/**
+ * A networking plugin that does not put its IPs on an interface and cannot list them
+ */
+type addresslessNet struct {
+	up, down int
+}
+
+func (n *addresslessNet) Name() string                                 { return "addressless" }
+func (n *addresslessNet) Version() float64                             { return 1.0 }
+func (n *addresslessNet) BringUpIPs(iface string, ips []string) error   { n.up++; return nil }
+func (n *addresslessNet) BringDownIPs(iface string, ips []string) error { n.down++; return nil }
+
+func TestReconcileAddresslessPlugin(t *testing.T) {
+	hostname := utils.GetHostname()
+	noAnnounce := 0
+	gconf.SetConfig(Config{
+		localNode:     hostname,
+		Groups:        map[string][]string{"group1": {"10.0.0.5/24"}},
+		GroupSettings: map[string]GroupSettings{"group1": {AnnounceCount: &noAnnounce}},
+		Nodes:         map[string]Node{hostname: {IPGroups: map[string][]string{"eth9": {"group1"}}}},
+	})
+	defer gconf.SetConfig(Config{})
+	plugin := &addresslessNet{}
+	pulse = &Pulse{Plugins: &Plugins{modules: []*Plugin{{Name: plugin.Name(), Type: PluginNetworking, Plugin: plugin}}}}
+	defer func() { pulse = nil }()
+	heldGroups = groupState{}
+	// Without knowing what the plugin holds every IP would look missing
+	if drift := localDrift(func(string) bool { return true }); len(drift) != 0 {
+		t.Errorf("expected no drift for a plugin that cannot list its IPs, got %v", drift)
+	}
+	m := &Memberlist{}
+	m.AddMember(hostname, &Client{})
+	m.reconcile()
+	local, _ := m.getLocalMember()
+	local.setStatus(proto.MemberStatus_ACTIVE)
+	m.applyGroupOwners()
+	m.applyGroupOwners()
+	m.reconcile()
+	if plugin.up != 1 || plugin.down != 0 {
+		t.Errorf("expected the group to be brought up once, got %d up and %d down", plugin.up, plugin.down)
+	}
+	local.setStatus(proto.MemberStatus_PASSIVE)
+	m.applyGroupOwners()
+	m.applyGroupOwners()
+	if plugin.up != 1 || plugin.down != 1 {
+		t.Errorf("expected the group to be brought down once, got %d up and %d down", plugin.up, plugin.down)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"github.com/Syleron/PulseHA/proto"
+	"github.com/Syleron/PulseHA/src/checks"
+	log "github.com/Sirupsen/logrus"
+	"time"
+)
+
+/**
+ * The health score of a node with nothing failing
+ */
+const maxScore = 100
+
+/**
+ * Calculate a health score from our failing checks and links.
+ * The score never drops below zero.
+ */
+func healthScore(results []checks.Result, linksDown int, scoring Scoring) int32 {
+	score := maxScore - linksDown*scoring.LinkWeight
+	for _, result := range results {
+		if !result.Healthy {
+			score -= result.Weight
+		}
+	}
+	if score < 0 {
+		score = 0
+	}
+	return int32(score)
+}
+
+/**
+ * Calculate the health score of the local node
+ */
+func localScore() int32 {
+	config := gconf.GetConfig()
+	return healthScore(localChecks.Results(), len(localLinksDown()), config.Scoring)
+}
+
+/**
+ * Keep our health score up to date. If we are the active and another member
+ * beats our score by the configured margin the active role is handed to it.
+ */
+func (m *Memberlist) monitorScore() bool {
+	localMember, err := m.getLocalMember()
+	if err != nil {
+		log.Debug("Memberlist:monitorScore() Score monitoring has stopped as it seems we are no longer in a cluster")
+		return true
+	}
+	score := localScore()
+	localMember.setScore(score)
+	margin := gconf.GetConfig().Scoring.Margin
+	if margin <= 0 || localMember.getStatus() != proto.MemberStatus_ACTIVE || clusterMaintenance() || failOverDamped() {
+		return false
+	}
+	next, err := m.getNextActiveMember()
+	if err != nil || next.getScore() < score+int32(margin) {
+		return false
+	}
+	// Give the new active a chance to settle before we consider handing over again
+	m.Lock()
+	if time.Since(m.lastScoreHandover) < failOverLimit() {
+		m.Unlock()
+		return false
+	}
+	m.lastScoreHandover = time.Now()
+	m.Unlock()
+	log.Warnf("Member %s has a health score of %d against our %d. Handing over the active role", next.getHostname(), next.getScore(), score)
+	if err := m.PromoteMember(next.getHostname()); err != nil {
+		log.Warnf("Unable to hand over to %s: %s", next.getHostname(), err.Error())
+	}
+	return false
+}
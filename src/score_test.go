@@ -0,0 +1,41 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"github.com/Syleron/PulseHA/src/checks"
+	"testing"
+)
+
+func TestHealthScore(t *testing.T) {
+	scoring := Scoring{LinkWeight: 50}
+	results := []checks.Result{
+		{Name: "nginx", Healthy: false, Weight: 30},
+		{Name: "disk", Healthy: true, Weight: 20},
+		{Name: "cron", Healthy: false},
+	}
+	if score := healthScore(nil, 0, scoring); score != maxScore {
+		t.Errorf("expected a full score with nothing failing, got %d", score)
+	}
+	if score := healthScore(results, 0, scoring); score != 70 {
+		t.Errorf("expected a score of 70, got %d", score)
+	}
+	if score := healthScore(results, 2, scoring); score != 0 {
+		t.Errorf("expected the score to stop at zero, got %d", score)
+	}
+}
@@ -40,6 +40,11 @@ type Server struct {
 	sync.Mutex
 	Server      *grpc.Server
 	Listener    net.Listener
+	// Listeners for the local node's additional heartbeat addresses
+	HeartbeatListeners []net.Listener
+	// The last round of health checks we acted on
+	lastHCTerm  uint64
+	lastHCRound uint64
 	Memberlist  *Memberlist
 	HCScheduler func()
 }
@@ -80,7 +85,26 @@ func (s *Server) Setup() {
 		s.Server = grpc.NewServer()
 	}
 	proto.RegisterServerServer(s.Server, s)
+	// Accept health checks on each of our additional heartbeat addresses
+	for _, address := range config.LocalNode().heartbeatAddresses()[1:] {
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			log.Errorf("Failed to listen on heartbeat address %s: %s", address, err)
+			continue
+		}
+		s.HeartbeatListeners = append(s.HeartbeatListeners, listener)
+		log.Info("Accepting health checks on " + address)
+		go s.Server.Serve(listener)
+	}
 	s.Memberlist.Setup()
+	// Keep our floating IPs in line with our role
+	go utils.DynamicScheduler(s.Memberlist.reconcile, reconcileInterval)
+	// Watch the links carrying our floating IPs
+	go utils.DynamicScheduler(s.Memberlist.monitorLinks, healthCheckInterval)
+	// Hand over if the services we front fail their tracked checks
+	go utils.DynamicScheduler(s.Memberlist.monitorChecks, healthCheckInterval)
+	// Keep our health score up to date and yield to healthier members
+	go utils.DynamicScheduler(s.Memberlist.monitorScore, healthCheckInterval)
 	log.Info("PulseHA initialised on " + config.LocalNode().IP + ":" + config.LocalNode().Port)
 	s.Server.Serve(s.Listener)
 }
@@ -92,6 +116,9 @@ func (s *Server) shutdown() {
 	log.Debug("Shutting down server")
 	s.Server.GracefulStop()
 	s.Listener.Close()
+	for _, listener := range s.HeartbeatListeners {
+		listener.Close()
+	}
 }
 
 /**
@@ -99,11 +126,22 @@ Perform appr. health checks
 */
 func (s *Server) HealthCheck(ctx context.Context, in *proto.PulseHealthCheck) (*proto.PulseHealthCheck, error) {
 	log.Debug("Server:HealthCheck() Receiving health check")
+	heartbeats.record(in.Path, time.Now())
 	s.Lock()
 	defer s.Unlock()
+	localMember := s.Memberlist.GetMemberByHostname(gconf.getLocalNode())
+	// The same health check arrives over each heartbeat path so only act on the first
+	if in.Round != 0 && in.Term == s.lastHCTerm && in.Round == s.lastHCRound {
+		return healthCheckResponse(localMember), nil
+	}
+	s.lastHCTerm, s.lastHCRound = in.Term, in.Round
+	// A higher term means a newer promotion has taken place so we must yield
+	if term.Observe(in.Term) && localMember.getStatus() == proto.MemberStatus_ACTIVE {
+		log.Warn("Received a health check with a higher term. Yielding the active role")
+		localMember.makePassive()
+	}
 	activeHostname, _ := s.Memberlist.getActiveMember()
 	if activeHostname != gconf.getLocalNode() {
-		localMember := s.Memberlist.GetMemberByHostname(gconf.getLocalNode())
 		// make passive to reset the networking
 		if _, activeMember := s.Memberlist.getActiveMember(); activeMember == nil {
 			log.Info("Local node is passive")
@@ -111,21 +149,37 @@ func (s *Server) HealthCheck(ctx context.Context, in *proto.PulseHealthCheck) (*
 		}
 		localMember.setLastHCResponse(time.Now())
 		s.Memberlist.update(in.Memberlist)
+		if activeActive() {
+			s.Memberlist.updateGroupOwners(in.Owners)
+			s.Memberlist.applyGroupOwners()
+		}
+	} else if in.Term < term.Get() {
+		// The sender will yield once it sees our term in the response
+		log.Warn("Received a health check from an active member with a stale term")
 	} else {
 		log.Warn("Active node mismatch")
 		hostname := getFailOverCountWinner(in.Memberlist)
 		log.Info("Member " + hostname + " has been determined as the correct active node.")
 		if hostname != gconf.getLocalNode() {
-			member, _ := s.Memberlist.getLocalMember()
-			member.makePassive()
+			localMember.makePassive()
 		} else {
-			localMember, _ := pulse.getMemberlist().getLocalMember()
 			localMember.setLastHCResponse(time.Time{})
 		}
 	}
+	return healthCheckResponse(localMember), nil
+}
+
+/**
+ * Returns our response to a health check
+ */
+func healthCheckResponse(localMember *Member) *proto.PulseHealthCheck {
 	return &proto.PulseHealthCheck{
-		Success: true,
-	}, nil
+		Success:   true,
+		Term:      term.Get(),
+		LinkDown:  localMember.getLinkDown(),
+		Unhealthy: localMember.getUnhealthy(),
+		Score:     localMember.getScore(),
+	}
 }
 
 /**
@@ -229,6 +283,8 @@ func (s *Server) ConfigSync(ctx context.Context, in *proto.PulseConfigSync) (*pr
 	gconf.Save()
 	// Update our member list
 	s.Memberlist.Reload()
+	// Pick up any changes to our health checks
+	startChecks()
 	// Let the logs know
 	log.Info("Successfully r-synced local config")
 	// Return with yay
@@ -244,6 +300,24 @@ func (s *Server) Promote(ctx context.Context, in *proto.PulsePromote) (*proto.Pu
 	log.Debug("Server:MakeActive() Making node active")
 	s.Lock()
 	defer s.Unlock()
+	// Move a single group. Only the active member decides group owners.
+	if in.Group != "" {
+		if activeHostname, _ := s.Memberlist.getActiveMember(); activeHostname != gconf.getLocalNode() {
+			return &proto.PulsePromote{
+				Success: false,
+				Message: "unable to promote group as we are not the active member",
+			}, nil
+		}
+		if err := s.Memberlist.PromoteGroup(in.Group, in.Member); err != nil {
+			return &proto.PulsePromote{
+				Success: false,
+				Message: err.Error(),
+			}, nil
+		}
+		return &proto.PulsePromote{
+			Success: true,
+		}, nil
+	}
 	if in.Member != gconf.getLocalNode() {
 		return &proto.PulsePromote{
 			Success: false,
@@ -255,6 +329,7 @@ func (s *Server) Promote(ctx context.Context, in *proto.PulsePromote) (*proto.Pu
 			Success: false,
 		}, nil
 	}
+	term.Observe(in.Term)
 	success := member.makeActive()
 	log.Info(in.Member + " has been promoted to active")
 	return &proto.PulsePromote{
@@ -280,6 +355,7 @@ func (s *Server) MakePassive(ctx context.Context, in *proto.PulsePromote) (*prot
 			Success: false,
 		}, nil
 	}
+	term.Observe(in.Term)
 	success := member.makePassive()
 	log.Info(in.Member + " has been demoted to passive")
 	return &proto.PulsePromote{
@@ -320,3 +396,24 @@ func (s *Server) BringDownIP(ctx context.Context, in *proto.PulseBringIP) (*prot
 	}
 	return &proto.PulseBringIP{Success: success, Message: msg}, nil
 }
+
+/**
+Vote on whether the active member is unreachable from this node
+Note: Do not lock the server here as a vote can be requested while a health check is in flight.
+ */
+func (s *Server) QuorumVote(ctx context.Context, in *proto.PulseQuorum) (*proto.PulseQuorum, error) {
+	log.Debug("Server:QuorumVote() " + in.Candidate + " requested a quorum vote on " + in.Active)
+	localMember, err := s.Memberlist.getLocalMember()
+	if err != nil {
+		return &proto.PulseQuorum{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	unreachable := s.Memberlist.activeUnreachable(localMember, in.Active)
+	log.Debugf("Server:QuorumVote() Voting active %s unreachable: %t", in.Active, unreachable)
+	return &proto.PulseQuorum{
+		Success:     true,
+		Unreachable: unreachable,
+	}, nil
+}
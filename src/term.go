@@ -0,0 +1,119 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+/**
+The cluster term is incremented on every promotion and carried in each health check.
+Any node that sees a higher term than its own must yield.
+*/
+type clusterTerm struct {
+	sync.Mutex
+	value uint64
+}
+
+var term clusterTerm
+
+/**
+Returns the location of the persisted term
+*/
+func termFile() string {
+	dir, err := filepath.Abs(filepath.Dir(os.Args[0]))
+	if err != nil {
+		log.Fatal(err)
+	}
+	return dir + "/cluster.term"
+}
+
+/**
+Load the persisted term from disk
+*/
+func (t *clusterTerm) Load() {
+	t.Lock()
+	defer t.Unlock()
+	b, err := ioutil.ReadFile(termFile())
+	if err != nil {
+		log.Debug("Term:Load() No persisted term found. Starting from zero")
+		t.value = 0
+		return
+	}
+	value, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		log.Errorf("Unable to parse the persisted cluster term: %s", err)
+		t.value = 0
+		return
+	}
+	t.value = value
+}
+
+/**
+Persist the term to disk
+Note: Expects the lock to be held
+*/
+func (t *clusterTerm) save() {
+	err := ioutil.WriteFile(termFile(), []byte(strconv.FormatUint(t.value, 10)), 0644)
+	if err != nil {
+		log.Errorf("Unable to persist the cluster term: %s", err)
+	}
+}
+
+/**
+Returns the current term
+*/
+func (t *clusterTerm) Get() uint64 {
+	t.Lock()
+	defer t.Unlock()
+	return t.value
+}
+
+/**
+Increment the term. Called whenever the local node is promoted.
+*/
+func (t *clusterTerm) Increment() uint64 {
+	t.Lock()
+	defer t.Unlock()
+	t.value++
+	t.save()
+	log.Debugf("Term:Increment() Cluster term is now %d", t.value)
+	return t.value
+}
+
+/**
+Adopt a term seen from another node if it is higher than our own.
+Returns true if the observed term was higher.
+*/
+func (t *clusterTerm) Observe(value uint64) bool {
+	t.Lock()
+	defer t.Unlock()
+	if value <= t.value {
+		return false
+	}
+	log.Debugf("Term:Observe() Adopting higher cluster term %d (was %d)", value, t.value)
+	t.value = value
+	t.save()
+	return true
+}
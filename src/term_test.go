@@ -0,0 +1,40 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import "testing"
+
+func TestTermPersistence(t *testing.T) {
+	ct := &clusterTerm{}
+	ct.Load()
+	start := ct.Get()
+	if got := ct.Increment(); got != start+1 {
+		t.Fatalf("Increment() = %d, want %d", got, start+1)
+	}
+	if ct.Observe(start) {
+		t.Error("Observe() should ignore a lower term")
+	}
+	if !ct.Observe(start + 5) {
+		t.Error("Observe() should adopt a higher term")
+	}
+	reloaded := &clusterTerm{}
+	reloaded.Load()
+	if reloaded.Get() != start+5 {
+		t.Errorf("reloaded term = %d, want %d", reloaded.Get(), start+5)
+	}
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"errors"
 	log "github.com/Sirupsen/logrus"
 	"runtime"
 	"github.com/Syleron/PulseHA/proto"
+	"github.com/Syleron/PulseHA/src/netUtils"
+	"github.com/Syleron/PulseHA/src/utils"
 	"time"
 )
 
@@ -13,6 +16,10 @@ Networking - Bring up the groups on the current node
 func makeMemberActive() error {
 	log.Debug("Utils:MakeMemberActive() Local node now passive")
 	configCopy := gconf.GetConfig()
+	// Each group is brought up by its owner when running active/active
+	if configCopy.Pulse.ActiveActive {
+		return nil
+	}
 	for name, node := range configCopy.Nodes {
 		if name == gconf.getLocalNode() {
 			for iface, groups := range node.IPGroups {
@@ -55,6 +62,81 @@ func bringUpIPs(iface string, ips []string) error {
 	return err
 }
 
+/**
+Returns the networking plugin if it can report the IPs it holds or nil if it cannot
+ */
+func ipLister() PluginNetAddresses {
+	plugin := pulse.Plugins.getNetworkingPlugin()
+	if plugin == nil {
+		log.Fatal("Missing network plugin")
+	}
+	lister, ok := plugin.Plugin.(PluginNetAddresses)
+	if !ok {
+		return nil
+	}
+	return lister
+}
+
+/**
+Add the []routes for a specific interface if the networking plugin supports routes
+ */
+func addRoutes(iface string, routes []string) error {
+	if len(routes) == 0 {
+		return nil
+	}
+	plugin := pulse.Plugins.getNetworkingPlugin()
+	if plugin == nil {
+		log.Fatal("Missing network plugin")
+	}
+	routing, ok := plugin.Plugin.(PluginNetRoutes)
+	if !ok {
+		return errors.New("the " + plugin.Name + " networking plugin does not support routes")
+	}
+	return routing.AddRoutes(iface, routes)
+}
+
+/**
+Remove the []routes for a specific interface if the networking plugin supports routes
+ */
+func deleteRoutes(iface string, routes []string) error {
+	if len(routes) == 0 {
+		return nil
+	}
+	plugin := pulse.Plugins.getNetworkingPlugin()
+	if plugin == nil {
+		log.Fatal("Missing network plugin")
+	}
+	routing, ok := plugin.Plugin.(PluginNetRoutes)
+	if !ok {
+		return errors.New("the " + plugin.Name + " networking plugin does not support routes")
+	}
+	return routing.DeleteRoutes(iface, routes)
+}
+
+/**
+Let our neighbours know that we now hold the []ips for a specific interface.
+IPv4 addresses are announced with a gratuitous ARP and IPv6 addresses with
+an unsolicited neighbour advertisement.
+ */
+func announceIPs(iface string, ips []string, settings GroupSettings) {
+	for i := 0; i < settings.announceCount(); i++ {
+		if i > 0 {
+			time.Sleep(time.Duration(settings.AnnounceInterval) * time.Millisecond)
+		}
+		for _, ip := range ips {
+			var err error
+			if utils.IsIPv6(ip) {
+				err = netUtils.SendUnsolicitedNA(iface, ip)
+			} else {
+				err = netUtils.SendGARP(iface, ip)
+			}
+			if err != nil {
+				log.Warnf("Unable to announce %s on %s: %s", ip, iface, err.Error())
+			}
+		}
+	}
+}
+
 /**
 Bring down an []ips for a specific interface
  */
@@ -98,16 +180,16 @@ func setLogLevel(level string) {
 }
 
 /**
-Determine who is the correct active node if more than one active is brought online
+Determine who is the correct active node if more than one active is brought online with the same term
+Note: The local node is assumed to be active. The highest priority active wins.
  */
 func getFailOverCountWinner(members []*proto.MemberlistMember) string {
+	config := gconf.GetConfig()
+	winner := gconf.getLocalNode()
 	for _, member := range members {
-		if member.Status != proto.MemberStatus_UNAVAILABLE {
-			tym, _ := time.Parse(time.RFC1123, member.LastReceived)
-			if tym == (time.Time{}) {
-				return member.Hostname
-			}
+		if member.Status == proto.MemberStatus_ACTIVE && nodeOutranks(config.Nodes, member.Hostname, winner) {
+			winner = member.Hostname
 		}
 	}
-	return ""
-}
\ No newline at end of file
+	return winner
+}
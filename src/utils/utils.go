@@ -62,22 +62,32 @@ func Execute(cmd string, args ...string) (string, error) {
 }
 
 /**
- * Function that validates an IPv4 and IPv6 address.
+ * Function that validates an IPv4 and IPv6 address in CIDR notation.
  *
- * @return bool
+ * @return error
  */
 func ValidIPAddress(ipAddress string) error {
 	ip, _, err := net.ParseCIDR(ipAddress)
 	if err != nil {
 		return errors.New("invalid CDIR address specified")
 	}
-	testInput := net.ParseIP(ip.String())
-	if testInput.To4() == nil {
+	if ip.To4() == nil && ip.To16() == nil {
 		return errors.New("invalid IP address")
 	}
 	return nil
 }
 
+/**
+ * Returns true if the address, with or without a CIDR suffix, is an IPv6 address.
+ */
+func IsIPv6(ipAddress string) bool {
+	ip := net.ParseIP(ipAddress)
+	if cidrIP, _, err := net.ParseCIDR(ipAddress); err == nil {
+		ip = cidrIP
+	}
+	return ip != nil && ip.To4() == nil
+}
+
 /**
  * Function to schedule the execution every x time as time.Duration.
  */
@@ -90,6 +100,19 @@ func Scheduler(method func() bool, delay time.Duration) {
 	}
 }
 
+/**
+ * Function to schedule the execution of a method with a delay that is re-evaluated
+ * before every execution. Allows the delay to be changed while the scheduler is running.
+ */
+func DynamicScheduler(method func() bool, delay func() time.Duration) {
+	for {
+		time.Sleep(delay())
+		if end := method(); end {
+			break
+		}
+	}
+}
+
 /**
  * Create folder if it doesn't already exist!
  * Returns true or false depending on whether the folder was created or not.
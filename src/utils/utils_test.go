@@ -0,0 +1,44 @@
+/*
+   PulseHA - HA Cluster Daemon
+   Copyright (C) 2017  Andrew Zak <andrew@pulseha.com>
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published
+   by the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package utils
+
+import "testing"
+
+func TestValidIPAddress(t *testing.T) {
+	valid := []string{"10.0.0.10/24", "2001:db8::10/64", "fe80::1/64"}
+	for _, ip := range valid {
+		if err := ValidIPAddress(ip); err != nil {
+			t.Errorf("expected %s to be valid: %s", ip, err)
+		}
+	}
+	invalid := []string{"10.0.0.10", "2001:db8::10", "10.0.0.300/24", "nonsense"}
+	for _, ip := range invalid {
+		if err := ValidIPAddress(ip); err == nil {
+			t.Errorf("expected %s to be invalid", ip)
+		}
+	}
+}
+
+func TestIsIPv6(t *testing.T) {
+	if !IsIPv6("2001:db8::10/64") || !IsIPv6("2001:db8::10") {
+		t.Error("expected IPv6 addresses to be detected")
+	}
+	if IsIPv6("10.0.0.10/24") || IsIPv6("::ffff:10.0.0.10") || IsIPv6("nonsense") {
+		t.Error("expected non IPv6 addresses to be rejected")
+	}
+}
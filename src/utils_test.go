@@ -16,3 +16,29 @@
    along with this program.  If not, see <http://www.gnu.org/licenses/>.
 */
 package main
+
+import (
+	"github.com/Syleron/PulseHA/proto"
+	"testing"
+)
+
+func TestGetFailOverCountWinner(t *testing.T) {
+	gconf.SetConfig(Config{
+		Nodes: map[string]Node{
+			"node-a": {Priority: 10},
+			"node-b": {Priority: 20},
+		},
+		localNode: "node-a",
+	})
+	members := []*proto.MemberlistMember{
+		{Hostname: "node-a", Status: proto.MemberStatus_PASSIVE},
+		{Hostname: "node-b", Status: proto.MemberStatus_ACTIVE},
+	}
+	if got := getFailOverCountWinner(members); got != "node-b" {
+		t.Errorf("getFailOverCountWinner() = %s, want node-b", got)
+	}
+	members[1].Status = proto.MemberStatus_UNAVAILABLE
+	if got := getFailOverCountWinner(members); got != "node-a" {
+		t.Errorf("getFailOverCountWinner() = %s, want node-a", got)
+	}
+}